@@ -37,7 +37,10 @@ fi
 # Check if web server exists, build if not
 if [ ! -f "web-server" ]; then
     echo -e "${BLUE}🔨 Building educational web server...${NC}"
-    go build -o web-server web/server.go
+    VERSION=$(git describe --tags --always --dirty 2>/dev/null || echo dev)
+    COMMIT=$(git rev-parse --short HEAD 2>/dev/null || true)
+    BUILD_DATE=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+    go build -ldflags "-X main.version=${VERSION} -X main.commit=${COMMIT} -X main.buildDate=${BUILD_DATE}" -o web-server ./web
     echo -e "${GREEN}✓ Web server built successfully${NC}"
 else
     echo -e "${GREEN}✓ Web server binary found${NC}"
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Attack simulations.
+// Each scenario runs a well-known attack against the learner's sandbox. With
+// the mitigation switched off the attack succeeds; with it on (the default)
+// it is blocked, and every step is returned so the learner can see why.
+
+type AttackStep struct {
+	Actor   string      `json:"actor"`
+	Action  string      `json:"action"`
+	Payload interface{} `json:"payload,omitempty"`
+	Result  string      `json:"result"`
+}
+
+type AttackScenario struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Mitigation  string `json:"mitigation"`
+
+	// run performs the attack and reports whether it succeeded
+	run func(s *EducationalServer, c *gin.Context, sb *sandbox, mitigated bool) (succeeded bool, steps []AttackStep)
+}
+
+var attackScenarios = []AttackScenario{
+	{
+		ID:          "token-replay",
+		Title:       "Token replay after logout",
+		Description: "An attacker captures a bearer token and replays it after the victim has logged out",
+		Mitigation:  "The resource server checks the denylist, so revoked tokens are rejected even though their signature and expiry are still valid",
+		run:         simulateTokenReplay,
+	},
+	{
+		ID:          "refresh-theft",
+		Title:       "Refresh token theft",
+		Description: "An attacker steals a refresh token and redeems it after the legitimate client already has",
+		Mitigation:  "Refresh tokens rotate on every use; presenting a spent one is treated as theft and revokes the whole token family",
+		run:         simulateRefreshTheft,
+	},
+	{
+		ID:          "alg-none",
+		Title:       "Unsigned token (alg=none)",
+		Description: "An attacker forges admin claims in a token whose header declares no signature algorithm",
+		Mitigation:  "The verifier only accepts HS256 and ignores the algorithm the token asks for",
+		run:         simulateAlgNone,
+	},
+	{
+		ID:          "csrf",
+		Title:       "Cross-site request forgery",
+		Description: "A malicious page makes the victim's browser send a state-changing request carrying the sandbox cookie",
+		Mitigation:  "Cookie-authenticated POSTs whose Origin is not this server are rejected; the cookie is also SameSite=Lax so modern browsers do not send it cross-site",
+		run:         simulateCSRF,
+	},
+}
+
+func findAttack(id string) (AttackScenario, bool) {
+	for _, scenario := range attackScenarios {
+		if scenario.ID == id {
+			return scenario, true
+		}
+	}
+	return AttackScenario{}, false
+}
+
+func simulateTokenReplay(s *EducationalServer, c *gin.Context, sb *sandbox, mitigated bool) (bool, []AttackStep) {
+	token, claims, err := sb.tokens.Issue(demoSubject, "read write demo", demoTokenTTL)
+	if err != nil {
+		return false, []AttackStep{{Actor: "victim", Action: "Log in", Result: "token issuance failed: " + err.Error()}}
+	}
+	steps := []AttackStep{
+		{Actor: "victim", Action: "Log in", Payload: map[string]string{"token": token}, Result: "token " + claims.ID + " issued"},
+		{Actor: "attacker", Action: "Capture the token from a proxy log", Result: "attacker holds a copy of the token"},
+	}
+
+	sb.tokens.Revoke(claims.ID)
+	steps = append(steps, AttackStep{Actor: "victim", Action: "Log out", Result: "token " + claims.ID + " added to the denylist"})
+
+	_, err = sb.tokens.Parse(token)
+	if !mitigated && errors.Is(err, errTokenRevoked) {
+		// A resource server that only checks signature and expiry
+		err = nil
+	}
+	if err != nil {
+		return false, append(steps, AttackStep{Actor: "attacker", Action: "Replay the token", Result: "rejected: " + err.Error()})
+	}
+	return true, append(steps, AttackStep{Actor: "attacker", Action: "Replay the token", Result: "accepted as " + claims.Subject})
+}
+
+func simulateRefreshTheft(s *EducationalServer, c *gin.Context, sb *sandbox, mitigated bool) (bool, []AttackStep) {
+	// Rotation with reuse detection is the mitigation
+	rotate := mitigated
+
+	stolen := sb.tokens.IssueRefresh(demoSubject, "read write demo")
+	steps := []AttackStep{
+		{Actor: "victim", Action: "Log in", Payload: map[string]string{"refresh_token": stolen}, Result: "refresh token issued"},
+		{Actor: "attacker", Action: "Steal the refresh token from browser storage", Result: "attacker holds " + stolen},
+	}
+
+	_, claims, current, err := sb.tokens.Refresh(stolen, rotate)
+	if err != nil {
+		return false, append(steps, AttackStep{Actor: "victim", Action: "Refresh the access token", Result: "refresh failed: " + err.Error()})
+	}
+	steps = append(steps, AttackStep{
+		Actor:   "victim",
+		Action:  "Refresh the access token",
+		Payload: map[string]string{"grant_type": "refresh_token", "refresh_token": stolen},
+		Result:  "access token " + claims.ID + " issued; current refresh token is " + current,
+	})
+
+	_, claims, _, err = sb.tokens.Refresh(stolen, rotate)
+	redeem := AttackStep{
+		Actor:   "attacker",
+		Action:  "Redeem the stolen refresh token",
+		Payload: map[string]string{"grant_type": "refresh_token", "refresh_token": stolen},
+	}
+	if err == nil {
+		redeem.Result = "accepted: access token " + claims.ID + " issued to the attacker"
+		return true, append(steps, redeem)
+	}
+	redeem.Result = "rejected: " + err.Error()
+	steps = append(steps, redeem)
+
+	_, _, _, err = sb.tokens.Refresh(current, rotate)
+	result := "accepted"
+	if err != nil {
+		result = "rejected: " + err.Error() + ", so the victim signs in again and the attacker is locked out"
+	}
+	return false, append(steps, AttackStep{
+		Actor:   "victim",
+		Action:  "Refresh with the current refresh token",
+		Payload: map[string]string{"grant_type": "refresh_token", "refresh_token": current},
+		Result:  result,
+	})
+}
+
+func simulateAlgNone(s *EducationalServer, c *gin.Context, sb *sandbox, mitigated bool) (bool, []AttackStep) {
+	now := time.Now()
+	forged := TokenClaims{
+		ID:        "edu_token_forged",
+		Issuer:    demoIssuer,
+		Subject:   "alice@example.com",
+		Audience:  demoAudience,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(demoTokenTTL).Unix(),
+		Scope:     "read write demo delegate admin",
+	}
+	header, _ := json.Marshal(tokenHeader{Algorithm: "none", Type: "JWT"})
+	payload, _ := json.Marshal(forged)
+	token := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+
+	steps := []AttackStep{{
+		Actor:   "attacker",
+		Action:  "Forge an unsigned token with admin scope",
+		Payload: map[string]interface{}{"header": map[string]string{"alg": "none", "typ": "JWT"}, "claims": forged, "token": token},
+		Result:  "token has an empty signature",
+	}}
+
+	if !mitigated {
+		// A verifier that lets the token choose its own algorithm
+		return true, append(steps, AttackStep{
+			Actor:  "resource server",
+			Action: "Verify the token using the algorithm from its header",
+			Result: "accepted: alg is none, so no signature is checked and the attacker acts as " + forged.Subject + " with scope \"" + forged.Scope + "\"",
+		})
+	}
+
+	_, err := sb.tokens.Parse(token)
+	return false, append(steps, AttackStep{
+		Actor:  "resource server",
+		Action: "Verify the token with HS256 only",
+		Result: "rejected: " + err.Error(),
+	})
+}
+
+func simulateCSRF(s *EducationalServer, c *gin.Context, sb *sandbox, mitigated bool) (bool, []AttackStep) {
+	const attackerOrigin = "https://attacker.example"
+	base := strings.TrimSuffix(c.FullPath(), "/attacks/:id/run")
+	body := `{"subject":"alice@example.com"}`
+
+	// The forged request is what the victim's browser would send when the
+	// attacker's page auto-submits a form: the sandbox cookie, a foreign Origin
+	forged := httptest.NewRequest(http.MethodPost, base+"/demo/token/create", strings.NewReader(body))
+	forged.Host = c.Request.Host
+	forged.Header.Set("Content-Type", "application/json")
+	forged.Header.Set("Origin", attackerOrigin)
+	forged.AddCookie(&http.Cookie{Name: sandboxCookie, Value: sb.ID})
+	forged = markSimulated(forged)
+	recorder := httptest.NewRecorder()
+
+	// Blocked (or not) by the origin check in sandboxSession
+	s.router.ServeHTTP(recorder, forged)
+
+	steps := []AttackStep{
+		{Actor: "victim", Action: "Visit " + attackerOrigin + " while signed in to the demo", Result: "the page auto-submits a form to this server"},
+	}
+	succeeded := recorder.Code == http.StatusOK
+	result := "rejected with status " + http.StatusText(recorder.Code)
+	if succeeded {
+		result = "accepted: the victim's sandbox issued an admin token for alice@example.com without the victim's consent"
+	}
+	return succeeded, append(steps, AttackStep{
+		Actor:  "victim's browser",
+		Action: "POST " + base + "/demo/token/create",
+		Payload: map[string]interface{}{
+			"headers": map[string]string{"Origin": attackerOrigin, "Cookie": sandboxCookie + "=" + sb.ID},
+			"body":    json.RawMessage(body),
+		},
+		Result: result,
+	})
+}
+
+func (s *EducationalServer) listAttacks(c *gin.Context) {
+	sb := currentSandbox(c)
+
+	scenarios := []map[string]interface{}{}
+	for _, scenario := range attackScenarios {
+		scenarios = append(scenarios, map[string]interface{}{
+			"id":                 scenario.ID,
+			"title":              scenario.Title,
+			"description":        scenario.Description,
+			"mitigation":         scenario.Mitigation,
+			"mitigation_enabled": sb.MitigationEnabled(scenario.ID),
+		})
+	}
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Attack scenarios retrieved",
+		Data:        map[string]interface{}{"attacks": scenarios},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) setAttackMitigation(c *gin.Context) {
+	scenario, exists := findAttack(c.Param("id"))
+	if !exists {
+		c.JSON(http.StatusNotFound, DemoResponse{
+			Success:     false,
+			Message:     "Attack scenario not found: " + c.Param("id"),
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	var request struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.Enabled == nil {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Request body must be {\"enabled\": true|false}",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	sb := currentSandbox(c)
+	sb.SetMitigation(scenario.ID, *request.Enabled)
+	recordActivity(c, "attack.mitigation", map[string]interface{}{
+		"attack":  scenario.ID,
+		"enabled": *request.Enabled,
+	})
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success: true,
+		Message: "Mitigation updated",
+		Data: map[string]interface{}{
+			"attack":             scenario.ID,
+			"mitigation":         scenario.Mitigation,
+			"mitigation_enabled": *request.Enabled,
+		},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) runAttack(c *gin.Context) {
+	scenario, exists := findAttack(c.Param("id"))
+	if !exists {
+		c.JSON(http.StatusNotFound, DemoResponse{
+			Success:     false,
+			Message:     "Attack scenario not found: " + c.Param("id"),
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	sb := currentSandbox(c)
+	mitigated := sb.MitigationEnabled(scenario.ID)
+	succeeded, steps := scenario.run(s, c, sb, mitigated)
+
+	recordActivity(c, "attack.simulated", map[string]interface{}{
+		"attack":    scenario.ID,
+		"mitigated": mitigated,
+		"succeeded": succeeded,
+	})
+
+	explanation := "The attack succeeded because the mitigation is disabled. Enable it and run the attack again."
+	if !succeeded {
+		explanation = "The attack was blocked: " + scenario.Mitigation
+	}
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success: true,
+		Message: "Attack simulated",
+		Data: map[string]interface{}{
+			"attack":             scenario.ID,
+			"title":              scenario.Title,
+			"mitigation_enabled": mitigated,
+			"succeeded":          succeeded,
+			"steps":              steps,
+			"explanation":        explanation,
+		},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+// Authorization policies for the educational demo.
+// Policies are evaluated in order and the first one to reach a decision wins;
+// if none decides, the request is denied.
+
+type AuthzDecision struct {
+	Allowed bool   `json:"allowed"`
+	Policy  string `json:"policy"`
+	Reason  string `json:"reason"`
+}
+
+type authzPolicy struct {
+	Name string
+	// Evaluate reports whether the policy applies and, if so, its verdict.
+	Evaluate func(principal TokenClaims, action, resource string) (decided, allowed bool, reason string)
+}
+
+// anonymousPrincipal is used when an authorization check carries no token.
+var anonymousPrincipal = TokenClaims{
+	Subject: "anonymous",
+	Scope:   "read demo",
+}
+
+var demoPolicies = []authzPolicy{
+	{
+		Name: "deny_admin_in_demo",
+		Evaluate: func(principal TokenClaims, action, resource string) (bool, bool, string) {
+			if action != "admin" {
+				return false, false, ""
+			}
+			return true, false, "administrative actions are disabled in the educational demo"
+		},
+	},
+	{
+		Name: "educational_demo_policy",
+		Evaluate: func(principal TokenClaims, action, resource string) (bool, bool, string) {
+			if containsString(principal.Scopes(), action) {
+				return true, true, "token scope grants \"" + action + "\""
+			}
+			return true, false, "token scope does not include \"" + action + "\""
+		},
+	},
+}
+
+func evaluateAuthz(principal TokenClaims, action, resource string) AuthzDecision {
+	for _, policy := range demoPolicies {
+		if decided, allowed, reason := policy.Evaluate(principal, action, resource); decided {
+			return AuthzDecision{Allowed: allowed, Policy: policy.Name, Reason: reason}
+		}
+	}
+	return AuthzDecision{Allowed: false, Policy: "default_deny", Reason: "no policy matched"}
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
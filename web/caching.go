@@ -0,0 +1,59 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Conditional caching for slowly-changing reference data (examples,
+// architecture, docs, OpenAPI). The ETag is derived from the content itself,
+// so any change on the server invalidates cached copies automatically.
+
+// referenceMaxAge is how long clients may reuse reference data without revalidating.
+const referenceMaxAge = 5 * time.Minute
+
+// respondCacheable writes body as JSON with an ETag computed over etagSource
+// and answers 304 Not Modified when the client already holds that version.
+// DemoResponse handlers pass their Data as etagSource so the per-request
+// timestamp does not defeat caching.
+func respondCacheable(c *gin.Context, etagSource interface{}, body interface{}) {
+	encoded, err := json.Marshal(etagSource)
+	if err != nil {
+		c.JSON(http.StatusOK, body)
+		return
+	}
+
+	sum := sha256.Sum256(encoded)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(referenceMaxAge.Seconds())))
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, body)
+}
+
+// etagMatches implements the weak comparison used for If-None-Match.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
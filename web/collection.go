@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Postman collection generated from the route table.
+// Only documented routes (routeDocs) are exported, versioned routes once for
+// the latest version. The sandbox ID and admin token are collection variables
+// so they can be overridden from a Postman environment.
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// captureSandboxScript stores the sandbox ID the server assigns so later
+// requests keep using the same sandbox.
+var captureSandboxScript = []string{
+	"const sandboxId = pm.response.headers.get('" + sandboxHeader + "');",
+	"if (sandboxId) { pm.collectionVariables.set('sandboxId', sandboxId); }",
+}
+
+func (s *EducationalServer) serveCollection(c *gin.Context) {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+
+	collection := s.postmanCollection(scheme + "://" + c.Request.Host)
+	respondCacheable(c, collection, collection)
+}
+
+func (s *EducationalServer) postmanCollection(baseURL string) map[string]interface{} {
+	latest := latestAPIVersion()
+	folders := map[string][]interface{}{}
+
+	for _, route := range s.router.Routes() {
+		doc, exists := routeDocs[route.Method+" "+unversionedPath(route.Path)]
+		if !exists {
+			continue
+		}
+		path := route.Path
+		if versionSegment.MatchString(path) {
+			if !strings.HasPrefix(path, "/api/"+latest.Name+"/") {
+				continue
+			}
+			path = "/api/{{apiVersion}}/" + strings.TrimPrefix(path, "/api/"+latest.Name+"/")
+		}
+
+		folders[doc.Tag] = append(folders[doc.Tag], postmanItem(route.Method, path, doc))
+	}
+
+	tags := []string{}
+	for tag := range folders {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	items := []interface{}{}
+	for _, tag := range tags {
+		requests := folders[tag]
+		sort.Slice(requests, func(i, j int) bool {
+			return requests[i].(map[string]interface{})["name"].(string) < requests[j].(map[string]interface{})["name"].(string)
+		})
+		items = append(items, map[string]interface{}{"name": tag, "item": requests})
+	}
+
+	return map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":        "GAuth Educational Demo API",
+			"description": "Generated from the server's route table. Educational implementation only - not for production use.",
+			"schema":      postmanSchema,
+		},
+		"variable": []map[string]string{
+			{"key": "baseUrl", "value": baseURL},
+			{"key": "apiVersion", "value": latest.Name},
+			{"key": "sandboxId", "value": ""},
+			{"key": "adminToken", "value": ""},
+		},
+		"event": []map[string]interface{}{
+			{
+				"listen": "test",
+				"script": map[string]interface{}{"type": "text/javascript", "exec": captureSandboxScript},
+			},
+		},
+		"item": items,
+	}
+}
+
+func postmanItem(method, path string, doc routeDoc) map[string]interface{} {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	variables := []map[string]string{}
+	for _, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			variables = append(variables, map[string]string{"key": segment[1:], "value": ""})
+		}
+	}
+
+	url := map[string]interface{}{
+		"raw":  "{{baseUrl}}" + path,
+		"host": []string{"{{baseUrl}}"},
+		"path": segments,
+	}
+	if len(variables) > 0 {
+		url["variable"] = variables
+	}
+
+	headers := []map[string]string{}
+	if strings.HasPrefix(path, "/api/{{apiVersion}}/") {
+		headers = append(headers, map[string]string{"key": sandboxHeader, "value": "{{sandboxId}}"})
+	}
+
+	request := map[string]interface{}{
+		"method":      method,
+		"header":      headers,
+		"url":         url,
+		"description": doc.Summary,
+	}
+	if strings.HasPrefix(path, "/debug/") {
+		request["auth"] = map[string]interface{}{
+			"type":   "bearer",
+			"bearer": []map[string]string{{"key": "token", "value": "{{adminToken}}", "type": "string"}},
+		}
+	}
+	if method == http.MethodPost || method == http.MethodPut {
+		headers = append(headers, map[string]string{"key": "Content-Type", "value": "application/json"})
+		request["header"] = headers
+		request["body"] = map[string]interface{}{
+			"mode":    "raw",
+			"raw":     postmanExampleBody(doc),
+			"options": map[string]interface{}{"raw": map[string]string{"language": "json"}},
+		}
+	}
+
+	return map[string]interface{}{
+		"name":    method + " " + strings.Replace(path, "/api/{{apiVersion}}/educational", "", 1),
+		"request": request,
+	}
+}
+
+// postmanExampleBody fills the documented body fields with empty values of
+// their type.
+func postmanExampleBody(doc routeDoc) string {
+	body := map[string]interface{}{}
+	for field, fieldType := range doc.Required {
+		body[field] = postmanExampleValue(fieldType)
+	}
+	for field, fieldType := range doc.Optional {
+		body[field] = postmanExampleValue(fieldType)
+	}
+
+	raw, _ := json.MarshalIndent(body, "", "  ")
+	return string(raw)
+}
+
+func postmanExampleValue(fieldType string) interface{} {
+	switch fieldType {
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "object":
+		return map[string]interface{}{}
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Ops-only diagnostics: pprof, runtime metrics, the runtime log level and
+// fault injection.
+// When GAUTH_ADMIN_TOKEN is set every request must carry it as a bearer
+// token; otherwise only loopback callers addressing localhost are admitted.
+// Cross-origin requests are always rejected and no CORS headers are sent.
+
+// logLevel is adjustable at runtime and backs the default slog handler
+// installed in main; request access logs are written at info level, so
+// raising it to warn or above silences them.
+var logLevel = new(slog.LevelVar)
+
+var startedAt = time.Now()
+
+func (s *EducationalServer) setupDiagnosticsRoutes() {
+	diagnostics := s.router.Group("/debug", opsOnly(os.Getenv("GAUTH_ADMIN_TOKEN")))
+	{
+		diagnostics.GET("/runtime", s.runtimeMetrics)
+		diagnostics.GET("/loglevel", s.getLogLevel)
+		diagnostics.PUT("/loglevel", s.setLogLevel)
+		diagnostics.GET("/faults", s.getFaults)
+		diagnostics.PUT("/faults", s.setFault)
+		diagnostics.DELETE("/faults", s.clearFaults)
+
+		diagnostics.GET("/pprof/", gin.WrapF(pprof.Index))
+		diagnostics.GET("/pprof/cmdline", gin.WrapF(pprof.Cmdline))
+		diagnostics.GET("/pprof/profile", gin.WrapF(pprof.Profile))
+		diagnostics.GET("/pprof/symbol", gin.WrapF(pprof.Symbol))
+		diagnostics.POST("/pprof/symbol", gin.WrapF(pprof.Symbol))
+		diagnostics.GET("/pprof/trace", gin.WrapF(pprof.Trace))
+		diagnostics.GET("/pprof/:profile", func(c *gin.Context) {
+			pprof.Handler(c.Param("profile")).ServeHTTP(c.Writer, c.Request)
+		})
+	}
+}
+
+func opsOnly(adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// A page on another site must not be able to drive diagnostics
+		// through the operator's browser
+		if !sameOrigin(c.Request) {
+			c.AbortWithStatusJSON(http.StatusForbidden, DemoResponse{
+				Success:     false,
+				Message:     "Diagnostics reject cross-origin requests",
+				Educational: true,
+				Timestamp:   time.Now(),
+			})
+			return
+		}
+
+		if adminToken != "" {
+			// Loopback is not trusted once a token is configured: a reverse
+			// proxy on the same host would make every caller look local
+			presented, hasScheme := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if hasScheme && subtle.ConstantTimeCompare([]byte(presented), []byte(adminToken)) == 1 {
+				c.Next()
+				return
+			}
+		} else if ip := net.ParseIP(c.RemoteIP()); ip != nil && ip.IsLoopback() && localHost(c.Request.Host) {
+			// RemoteIP ignores X-Forwarded-For, which a client could forge;
+			// the Host check defeats DNS rebinding to a loopback address
+			c.Next()
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, DemoResponse{
+			Success:     false,
+			Message:     "Diagnostics are restricted to the admin token, or to localhost when no token is configured",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+	}
+}
+
+// localHost reports whether a Host header names this machine by a loopback
+// address or "localhost".
+func localHost(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// accessLogSkipper suppresses gin's access log when the log level is above info.
+func accessLogSkipper(c *gin.Context) bool {
+	return logLevel.Level() > slog.LevelInfo
+}
+
+func (s *EducationalServer) runtimeMetrics(c *gin.Context) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	metrics := map[string]interface{}{
+		"uptime":     time.Since(startedAt).String(),
+		"goroutines": runtime.NumGoroutine(),
+		"gomaxprocs": runtime.GOMAXPROCS(0),
+		"num_cpu":    runtime.NumCPU(),
+		"heap": map[string]interface{}{
+			"alloc_bytes":    mem.HeapAlloc,
+			"sys_bytes":      mem.HeapSys,
+			"objects":        mem.HeapObjects,
+			"total_alloc":    mem.TotalAlloc,
+			"next_gc_bytes":  mem.NextGC,
+			"num_gc":         mem.NumGC,
+			"last_pause_ns":  mem.PauseNs[(mem.NumGC+255)%256],
+			"pause_total_ns": mem.PauseTotalNs,
+		},
+		"build": currentBuildInfo(),
+	}
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Runtime metrics retrieved",
+		Data:        metrics,
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) getLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Current log level",
+		Data:        map[string]string{"level": strings.ToLower(logLevel.Level().String())},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) setLogLevel(c *gin.Context) {
+	var request struct {
+		Level string `json:"level"`
+	}
+	var level slog.Level
+	if err := c.ShouldBindJSON(&request); err != nil || level.UnmarshalText([]byte(request.Level)) != nil {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Level must be one of debug, info, warn, error",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	previous := logLevel.Level()
+	logLevel.Set(level)
+	slog.Warn("log level changed", "from", previous.String(), "to", level.String(), "remote_ip", c.RemoteIP())
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Log level updated",
+		Data:        map[string]string{"level": strings.ToLower(level.String())},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
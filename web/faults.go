@@ -0,0 +1,210 @@
+package main
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Latency and error injection for the educational API.
+// Rules are keyed by endpoint ("POST /demo/token/create") or "*" for every
+// endpoint without its own rule, and are managed through /debug/faults. With
+// no rules configured, requests are served without added delay.
+
+// anyEndpoint matches endpoints that have no rule of their own.
+const anyEndpoint = "*"
+
+type FaultRule struct {
+	Endpoint     string  `json:"endpoint"`
+	LatencyMinMs int     `json:"latency_min_ms"`
+	LatencyMaxMs int     `json:"latency_max_ms"`
+	ErrorRate    float64 `json:"error_rate"`
+	ErrorStatus  int     `json:"error_status"`
+}
+
+// delay draws a latency uniformly between the rule's bounds.
+func (r FaultRule) delay() time.Duration {
+	latency := r.LatencyMinMs
+	if r.LatencyMaxMs > r.LatencyMinMs {
+		latency += rand.IntN(r.LatencyMaxMs - r.LatencyMinMs + 1)
+	}
+	return time.Duration(latency) * time.Millisecond
+}
+
+type faultInjector struct {
+	mu    sync.RWMutex
+	rules map[string]FaultRule
+}
+
+func newFaultInjector() *faultInjector {
+	return &faultInjector{rules: map[string]FaultRule{}}
+}
+
+func (f *faultInjector) Rule(endpoint string) (FaultRule, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if rule, exists := f.rules[endpoint]; exists {
+		return rule, true
+	}
+	rule, exists := f.rules[anyEndpoint]
+	return rule, exists
+}
+
+func (f *faultInjector) Set(rule FaultRule) {
+	f.mu.Lock()
+	f.rules[rule.Endpoint] = rule
+	f.mu.Unlock()
+}
+
+// Clear removes the rule for endpoint, or every rule when endpoint is empty.
+func (f *faultInjector) Clear(endpoint string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if endpoint == "" {
+		f.rules = map[string]FaultRule{}
+		return
+	}
+	delete(f.rules, endpoint)
+}
+
+func (f *faultInjector) Rules() []FaultRule {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	rules := []FaultRule{}
+	for _, rule := range f.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Endpoint < rules[j].Endpoint })
+	return rules
+}
+
+// faultEndpoint names a request's route relative to the educational API,
+// e.g. "POST /demo/token/create".
+func faultEndpoint(method, fullPath string) string {
+	return method + " " + strings.TrimPrefix(unversionedPath(fullPath), "/api/educational")
+}
+
+// inject delays and fails educational API requests according to the rules.
+func (f *faultInjector) inject() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule, exists := f.Rule(faultEndpoint(c.Request.Method, c.FullPath()))
+		if !exists {
+			c.Next()
+			return
+		}
+
+		if delay := rule.delay(); delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-c.Request.Context().Done():
+				c.Abort()
+				return
+			}
+		}
+
+		if rule.ErrorRate > 0 && rand.Float64() < rule.ErrorRate {
+			c.Header("X-Fault-Injected", "true")
+			c.AbortWithStatusJSON(rule.ErrorStatus, DemoResponse{
+				Success:     false,
+				Message:     "Injected fault: " + http.StatusText(rule.ErrorStatus),
+				Educational: true,
+				Timestamp:   time.Now(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (s *EducationalServer) getFaults(c *gin.Context) {
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Fault injection rules",
+		Data:        map[string]interface{}{"rules": s.faults.Rules()},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) setFault(c *gin.Context) {
+	var rule FaultRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Invalid fault rule: " + err.Error(),
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	if rule.ErrorStatus == 0 {
+		rule.ErrorStatus = http.StatusServiceUnavailable
+	}
+
+	problem := ""
+	switch {
+	case rule.Endpoint != anyEndpoint && !s.isEducationalEndpoint(rule.Endpoint):
+		problem = "endpoint must be \"*\" or an educational API route such as \"POST /demo/token/create\""
+	case rule.LatencyMinMs < 0 || rule.LatencyMinMs > 30000 || rule.LatencyMaxMs < 0 || rule.LatencyMaxMs > 30000:
+		problem = "latency must be between 0 and 30000 ms"
+	case rule.LatencyMaxMs != 0 && rule.LatencyMaxMs < rule.LatencyMinMs:
+		problem = "latency_max_ms must not be below latency_min_ms"
+	case rule.ErrorRate < 0 || rule.ErrorRate > 1:
+		problem = "error_rate must be between 0 and 1"
+	case rule.ErrorStatus < 400 || rule.ErrorStatus > 599:
+		problem = "error_status must be a 4xx or 5xx status"
+	}
+	if problem != "" {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Invalid fault rule: " + problem,
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	if rule.LatencyMaxMs == 0 {
+		rule.LatencyMaxMs = rule.LatencyMinMs
+	}
+
+	s.faults.Set(rule)
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Fault injection rule updated",
+		Data:        rule,
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) clearFaults(c *gin.Context) {
+	s.faults.Clear(c.Query("endpoint"))
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Fault injection rules cleared",
+		Data:        map[string]interface{}{"rules": s.faults.Rules()},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) isEducationalEndpoint(endpoint string) bool {
+	for _, route := range s.router.Routes() {
+		path := unversionedPath(route.Path)
+		if strings.HasPrefix(path, "/api/educational/") && !strings.Contains(path, "*") && faultEndpoint(route.Method, route.Path) == endpoint {
+			return true
+		}
+	}
+	return false
+}
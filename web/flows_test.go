@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func findFlowStep(t *testing.T, flowName string, order int) FlowStep {
+	t.Helper()
+	for _, flow := range flows {
+		if flow.Name != flowName {
+			continue
+		}
+		for _, step := range flow.Steps {
+			if step.Order == order {
+				return step
+			}
+		}
+	}
+	t.Fatalf("flow %s has no step %d", flowName, order)
+	return FlowStep{}
+}
+
+// TestDelegationFlowMatchesServer checks that the example payloads of the
+// poa-delegation flow have the shape of the messages the demo really sends.
+func TestDelegationFlowMatchesServer(t *testing.T) {
+	s := NewEducationalServer("")
+
+	call := func(sandboxID, path, body string) (http.Header, map[string]interface{}) {
+		request := postJSON(path, body)
+		if sandboxID != "" {
+			request.Header.Set(sandboxHeader, sandboxID)
+		}
+		response := serve(t, s, request)
+
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(response.Body.Bytes(), &decoded); err != nil {
+			t.Fatalf("POST %s: %v", path, err)
+		}
+		return response.Header(), decoded
+	}
+	data := func(response map[string]interface{}) map[string]interface{} {
+		return response["data"].(map[string]interface{})
+	}
+
+	headers, created := call("", "/demo/token/create", `{"subject":"alice@example.com"}`)
+	sandboxID := headers.Get(sandboxHeader)
+	aliceToken := data(created)["token"].(string)
+
+	_, delegated := call(sandboxID, "/demo/token/delegate", `{"token":"`+aliceToken+`","delegate_to":"bob@example.com","scope":"read"}`)
+	bobToken := data(delegated)["token"].(string)
+	checkBody := `{"token":"` + bobToken + `","action":"read","resource":"documents"}`
+	_, allowed := call(sandboxID, "/demo/authz/check", checkBody)
+	call(sandboxID, "/demo/token/revoke", `{"token":"`+aliceToken+`"}`)
+	_, rejected := call(sandboxID, "/demo/authz/check", checkBody)
+
+	tests := []struct {
+		order  int
+		actual map[string]interface{}
+	}{
+		{3, data(delegated)},
+		{5, data(allowed)},
+		{8, rejected},
+	}
+	for _, test := range tests {
+		payload, _ := json.Marshal(findFlowStep(t, "poa-delegation", test.order).Payload)
+		var example map[string]interface{}
+		json.Unmarshal(payload, &example)
+
+		for key := range example {
+			if _, exists := test.actual[key]; !exists {
+				t.Errorf("step %d payload has %q, which the server does not send", test.order, key)
+			}
+		}
+	}
+
+	chain := data(allowed)["delegation_chain"].([]interface{})
+	example := findFlowStep(t, "poa-delegation", 5).Payload.(map[string]interface{})["delegation_chain"].([]string)
+	if len(chain) != len(example) {
+		t.Errorf("authz check delegation_chain has %d entries, flow example has %d", len(chain), len(example))
+	}
+	if got, want := rejected["message"], findFlowStep(t, "poa-delegation", 8).Payload.(map[string]interface{})["message"]; got != want {
+		t.Errorf("rejection message = %q, flow example says %q", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Token inspector.
+// Decodes a token without trusting it, then reports what the caller's sandbox
+// makes of it: signature validity, expiry and session/denylist status.
+
+// pasetoSignatureSizes is the size of the signature appended to public
+// PASETO payloads, by version.
+var pasetoSignatureSizes = map[string]int{"v1": 256, "v2": 64, "v3": 96, "v4": 64}
+
+func (s *EducationalServer) inspectToken(c *gin.Context) {
+	var request struct {
+		Token string `json:"token"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.Token == "" {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Request body must contain a token",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(request.Token, "Bearer "))
+
+	var (
+		result map[string]interface{}
+		err    error
+	)
+	if _, paseto := pasetoSignatureSizes[strings.SplitN(token, ".", 2)[0]]; paseto {
+		result, err = inspectPASETO(token)
+	} else {
+		result, err = inspectJWT(currentSandbox(c), token)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Token cannot be decoded: " + err.Error(),
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	recordActivity(c, "token.inspected", map[string]interface{}{
+		"format": result["format"],
+		"status": result["status"],
+	})
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Token inspected",
+		Data:        result,
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func inspectJWT(sb *sandbox, token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errTokenMalformed
+	}
+
+	// Decoded generically so unknown headers and claims are shown too
+	var header, claims map[string]interface{}
+	if decodeSegment(parts[0], &header) != nil || decodeSegment(parts[1], &claims) != nil {
+		return nil, errTokenMalformed
+	}
+
+	_, err := sb.tokens.Parse(token)
+	signatureValid := !errors.Is(err, errTokenAlgorithm) && !errors.Is(err, errTokenSignature)
+
+	status := "active"
+	switch {
+	case errors.Is(err, errTokenAlgorithm), errors.Is(err, errTokenSignature):
+		status = "untrusted"
+	case errors.Is(err, errTokenUnknown):
+		status = "unknown"
+	case errors.Is(err, errTokenRevoked):
+		status = "revoked"
+	case errors.Is(err, errParentRevoked):
+		status = "parent_revoked"
+	case errors.Is(err, errTokenExpired):
+		status = "expired"
+	}
+
+	result := map[string]interface{}{
+		"format":          "JWT",
+		"header":          header,
+		"claims":          claims,
+		"signature_valid": signatureValid,
+		"status":          status,
+		"denylisted":      status == "revoked" || status == "parent_revoked",
+	}
+	if err != nil {
+		result["reason"] = err.Error()
+	}
+
+	if exp, ok := claims["exp"].(float64); ok {
+		expiresAt := time.Unix(int64(exp), 0)
+		remaining := time.Until(expiresAt).Round(time.Second)
+		result["expires_at"] = expiresAt
+		result["expires_in_seconds"] = int64(remaining.Seconds())
+		result["expired"] = remaining <= 0
+		if remaining > 0 {
+			result["expires_in"] = remaining.String()
+		}
+	}
+
+	return result, nil
+}
+
+// inspectPASETO decodes public PASETO tokens. The demo only issues JWTs, so
+// signatures and sessions are never checked; local tokens are encrypted and
+// only their version and purpose can be shown.
+func inspectPASETO(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) < 3 || len(parts) > 4 {
+		return nil, errTokenMalformed
+	}
+
+	result := map[string]interface{}{
+		"format":          "PASETO",
+		"header":          map[string]string{"version": parts[0], "purpose": parts[1]},
+		"signature_valid": nil,
+		"status":          "unknown",
+		"denylisted":      false,
+		"reason":          "PASETO tokens are not issued by the educational demo, so only decoding is supported",
+	}
+	if len(parts) == 4 {
+		footer, err := base64.RawURLEncoding.DecodeString(parts[3])
+		if err != nil {
+			return nil, errTokenMalformed
+		}
+		result["footer"] = string(footer)
+	}
+
+	switch parts[1] {
+	case "local":
+		result["claims"] = nil
+		result["reason"] = "local PASETO tokens are encrypted; claims cannot be read without the key"
+	case "public":
+		raw, err := base64.RawURLEncoding.DecodeString(parts[2])
+		size := pasetoSignatureSizes[parts[0]]
+		if err != nil || len(raw) <= size {
+			return nil, errTokenMalformed
+		}
+		var claims map[string]interface{}
+		if err := json.Unmarshal(raw[:len(raw)-size], &claims); err != nil {
+			return nil, errTokenMalformed
+		}
+		result["claims"] = claims
+		if exp, ok := claims["exp"].(string); ok {
+			if expiresAt, err := time.Parse(time.RFC3339, exp); err == nil {
+				remaining := time.Until(expiresAt).Round(time.Second)
+				result["expires_at"] = expiresAt
+				result["expires_in_seconds"] = int64(remaining.Seconds())
+				result["expired"] = remaining <= 0
+			}
+		}
+	default:
+		return nil, errTokenMalformed
+	}
+
+	return result, nil
+}
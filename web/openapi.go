@@ -0,0 +1,436 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPI 3 document for the educational API.
+// The document is generated from the live route table, so only routes that
+// are actually mounted appear in it; routeDocs supplies the descriptions.
+// API routes are documented by their unversioned path so every mounted
+// version shares the same entry.
+
+type routeDoc struct {
+	Summary  string
+	Tag      string
+	Required map[string]string // required top-level JSON body fields and their JSON types
+	Optional map[string]string // optional body fields and their JSON types
+}
+
+// requiredFields returns the names of the required body fields, sorted.
+func (d routeDoc) requiredFields() []string {
+	fields := []string{}
+	for field := range d.Required {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+var routeDocs = map[string]routeDoc{
+	"GET /api/educational/health": {
+		Summary: "Educational server health and environment info",
+		Tag:     "system",
+	},
+	"POST /api/educational/demo/token/create": {
+		Summary:  "Issue a signed educational JWT",
+		Tag:      "tokens",
+		Optional: map[string]string{"subject": "string", "scope": "string", "ttl_seconds": "integer"},
+	},
+	"POST /api/educational/demo/token/validate": {
+		Summary:  "Validate an educational token by JWT or ID",
+		Tag:      "tokens",
+		Optional: map[string]string{"token": "string", "token_id": "string"},
+	},
+	"POST /api/educational/demo/token/revoke": {
+		Summary:  "Revoke an educational token by JWT or ID",
+		Tag:      "tokens",
+		Optional: map[string]string{"token": "string", "token_id": "string"},
+	},
+	"POST /api/educational/demo/token/delegate": {
+		Summary:  "Delegate power of attorney from a token to another sandbox user",
+		Tag:      "tokens",
+		Required: map[string]string{"token": "string", "delegate_to": "string"},
+		Optional: map[string]string{"scope": "string", "ttl_seconds": "integer"},
+	},
+	"POST /api/educational/demo/authz/check": {
+		Summary:  "Evaluate the demo authorization policies",
+		Tag:      "authorization",
+		Optional: map[string]string{"action": "string", "resource": "string", "token": "string"},
+	},
+	"POST /api/educational/inspect": {
+		Summary:  "Decode a JWT or PASETO and report signature, expiry and denylist status",
+		Tag:      "tokens",
+		Required: map[string]string{"token": "string"},
+	},
+	"GET /api/educational/sandbox": {
+		Summary: "Describe the caller's sandbox tenant",
+		Tag:     "sandbox",
+	},
+	"GET /api/educational/sandbox/activity": {
+		Summary: "Actions performed in the caller's sandbox",
+		Tag:     "sandbox",
+	},
+	"POST /api/educational/sandbox/reset": {
+		Summary: "Discard the caller's sandbox and start a fresh one",
+		Tag:     "sandbox",
+	},
+	"POST /api/educational/sandbox/console": {
+		Summary:  "Execute an educational API call against the caller's sandbox",
+		Tag:      "sandbox",
+		Required: map[string]string{"path": "string"},
+		Optional: map[string]string{"method": "string", "body": "object"},
+	},
+	"GET /api/educational/tutorials": {
+		Summary: "List tutorials with the caller's progress",
+		Tag:     "tutorials",
+	},
+	"GET /api/educational/tutorials/:id": {
+		Summary: "Tutorial steps and per-step progress",
+		Tag:     "tutorials",
+	},
+	"POST /api/educational/tutorials/:id/steps/:step/verify": {
+		Summary: "Check whether the learner completed a tutorial step in their sandbox",
+		Tag:     "tutorials",
+	},
+	"GET /api/educational/attacks": {
+		Summary: "List attack scenarios and the caller's mitigation settings",
+		Tag:     "attacks",
+	},
+	"POST /api/educational/attacks/:id/mitigation": {
+		Summary:  "Enable or disable the mitigation for an attack scenario in the caller's sandbox",
+		Tag:      "attacks",
+		Required: map[string]string{"enabled": "boolean"},
+	},
+	"POST /api/educational/attacks/:id/run": {
+		Summary: "Run an attack scenario against the caller's sandbox",
+		Tag:     "attacks",
+	},
+	"GET /api/educational/demo/examples": {
+		Summary: "List the examples catalog",
+		Tag:     "reference",
+	},
+	"GET /api/educational/demo/architecture": {
+		Summary: "Describe the GAuth architecture layers",
+		Tag:     "reference",
+	},
+	"GET /api/educational/flows": {
+		Summary: "List the protocol flows available as sequence data",
+		Tag:     "reference",
+	},
+	"GET /api/educational/flows/:name": {
+		Summary: "Actors, steps and example payloads of a protocol flow",
+		Tag:     "reference",
+	},
+	"GET /docs/": {
+		Summary: "Educational documentation index",
+		Tag:     "docs",
+	},
+	"GET /docs/rfc": {
+		Summary: "Implemented RFC standards",
+		Tag:     "docs",
+	},
+	"GET /api/dev/collection": {
+		Summary: "Postman collection generated from the route table",
+		Tag:     "docs",
+	},
+	"GET /api/version": {
+		Summary: "Build version, commit and date",
+		Tag:     "system",
+	},
+	"GET /debug/runtime": {
+		Summary: "Runtime metrics (ops only)",
+		Tag:     "diagnostics",
+	},
+	"GET /debug/loglevel": {
+		Summary: "Current log level (ops only)",
+		Tag:     "diagnostics",
+	},
+	"PUT /debug/loglevel": {
+		Summary:  "Change the log level at runtime (ops only)",
+		Tag:      "diagnostics",
+		Required: map[string]string{"level": "string"},
+	},
+	"GET /debug/faults": {
+		Summary: "Latency and error injection rules (ops only)",
+		Tag:     "diagnostics",
+	},
+	"PUT /debug/faults": {
+		Summary:  "Add or replace the latency and error injection rule for an endpoint (ops only)",
+		Tag:      "diagnostics",
+		Required: map[string]string{"endpoint": "string"},
+		Optional: map[string]string{"latency_min_ms": "integer", "latency_max_ms": "integer", "error_rate": "number", "error_status": "integer"},
+	},
+	"DELETE /debug/faults": {
+		Summary: "Remove one (?endpoint=) or all injection rules (ops only)",
+		Tag:     "diagnostics",
+	},
+	"GET /openapi.json": {
+		Summary: "This OpenAPI document",
+		Tag:     "docs",
+	},
+}
+
+func (s *EducationalServer) serveOpenAPI(c *gin.Context) {
+	spec := s.openAPISpec()
+	respondCacheable(c, spec, spec)
+}
+
+func (s *EducationalServer) openAPISpec() map[string]interface{} {
+	paths := map[string]interface{}{}
+	tags := map[string]bool{}
+
+	for _, route := range s.router.Routes() {
+		doc, exists := routeDocs[route.Method+" "+unversionedPath(route.Path)]
+		if !exists {
+			continue
+		}
+		tags[doc.Tag] = true
+
+		path, params := openAPIPath(route.Path)
+		item, _ := paths[path].(map[string]interface{})
+		if item == nil {
+			item = map[string]interface{}{}
+			paths[path] = item
+		}
+
+		operation := map[string]interface{}{
+			"summary":     doc.Summary,
+			"operationId": operationID(route.Handler, routeVersion(route.Path)),
+			"tags":        []string{doc.Tag},
+			"responses":   openAPIResponses(route.Path, doc),
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+		if route.Method == http.MethodPost || route.Method == http.MethodPut {
+			operation["requestBody"] = openAPIRequestBody(doc)
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+
+	tagList := []map[string]string{}
+	for tag := range tags {
+		tagList = append(tagList, map[string]string{"name": tag})
+	}
+	sort.Slice(tagList, func(i, j int) bool { return tagList[i]["name"] < tagList[j]["name"] })
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "GAuth Educational Demo API",
+			"version":     "RFC-0150-Educational",
+			"description": "Educational implementation only - not for production use",
+		},
+		"tags":  tagList,
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"DemoResponse": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"success", "message", "educational", "timestamp"},
+					"properties": map[string]interface{}{
+						"success":     map[string]string{"type": "boolean"},
+						"message":     map[string]string{"type": "string"},
+						"data":        map[string]string{"type": "object"},
+						"educational": map[string]string{"type": "boolean"},
+						"timestamp":   map[string]string{"type": "string", "format": "date-time"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// openAPIPath converts a gin path ("/flows/:name") into OpenAPI form
+// ("/flows/{name}") and returns the matching path parameters.
+func openAPIPath(ginPath string) (string, []map[string]interface{}) {
+	segments := strings.Split(ginPath, "/")
+	params := []map[string]interface{}{}
+
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+			continue
+		}
+		name := segment[1:]
+		segments[i] = "{" + name + "}"
+		params = append(params, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]string{"type": "string"},
+		})
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable operation id from the gin handler name and,
+// for versioned routes, the version, so every mounted version gets its own
+// id: "main.(*EducationalServer).healthCheck-fm" on v1 becomes "healthCheckV1".
+func operationID(handler, version string) string {
+	name := strings.TrimSuffix(handler[strings.LastIndex(handler, ".")+1:], "-fm")
+	if version == "" {
+		return name
+	}
+	return name + strings.ToUpper(version[:1]) + version[1:]
+}
+
+func openAPIResponses(path string, doc routeDoc) map[string]interface{} {
+	// API routes wrap their payload in DemoResponse; docs and developer tooling
+	// routes return plain objects
+	schema := map[string]interface{}{"type": "object"}
+	if strings.HasPrefix(path, "/api/") && !strings.HasPrefix(path, "/api/dev/") {
+		schema = map[string]interface{}{"$ref": "#/components/schemas/DemoResponse"}
+	}
+
+	responses := map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "Successful educational response",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schema},
+			},
+		},
+	}
+	if len(doc.Required) > 0 {
+		responses["400"] = map[string]interface{}{
+			"description": "Missing or malformed request fields",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/DemoResponse"},
+				},
+			},
+		}
+	}
+	return responses
+}
+
+func openAPIRequestBody(doc routeDoc) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for field, fieldType := range doc.Required {
+		properties[field] = map[string]string{"type": fieldType}
+	}
+	for field, fieldType := range doc.Optional {
+		properties[field] = map[string]string{"type": fieldType}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(doc.Required) > 0 {
+		schema["required"] = doc.requiredFields()
+	}
+
+	return map[string]interface{}{
+		"required": len(doc.Required) > 0,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// openAPIValidation checks JSON request bodies against the fields documented
+// in routeDocs: required fields must be present, and documented fields must
+// have their documented JSON type. In strict mode mismatches are rejected with
+// 400; otherwise they are only reported in the X-OpenAPI-Validation header.
+func openAPIValidation(strict bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		doc, exists := routeDocs[c.Request.Method+" "+unversionedPath(c.FullPath())]
+		if !exists || len(doc.Required)+len(doc.Optional) == 0 {
+			c.Next()
+			return
+		}
+
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		problems := requestBodyProblems(doc, body)
+		if len(problems) == 0 {
+			c.Next()
+			return
+		}
+
+		problem := strings.Join(problems, "; ")
+		if strict {
+			c.AbortWithStatusJSON(http.StatusBadRequest, DemoResponse{
+				Success:     false,
+				Message:     "Request does not match OpenAPI document: " + problem,
+				Educational: true,
+				Timestamp:   time.Now(),
+			})
+			return
+		}
+
+		c.Header("X-OpenAPI-Validation", problem)
+		c.Next()
+	}
+}
+
+// requestBodyProblems describes how a JSON body deviates from doc. An empty
+// body is fine for routes without required fields.
+func requestBodyProblems(doc routeDoc, body []byte) []string {
+	if len(doc.Required) == 0 && len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var request map[string]interface{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		if len(doc.Required) == 0 {
+			return []string{"body is not a JSON object"}
+		}
+		return []string{"missing required fields: " + strings.Join(doc.requiredFields(), ", ")}
+	}
+
+	missing, mistyped := []string{}, []string{}
+	for _, field := range doc.requiredFields() {
+		if _, ok := request[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	for field, value := range request {
+		fieldType, documented := doc.Required[field]
+		if !documented {
+			fieldType, documented = doc.Optional[field]
+		}
+		if documented && !hasJSONType(value, fieldType) {
+			mistyped = append(mistyped, field+" must be "+fieldType)
+		}
+	}
+	sort.Strings(mistyped)
+
+	problems := []string{}
+	if len(missing) > 0 {
+		problems = append(problems, "missing required fields: "+strings.Join(missing, ", "))
+	}
+	if len(mistyped) > 0 {
+		problems = append(problems, "wrong field types: "+strings.Join(mistyped, ", "))
+	}
+	return problems
+}
+
+// hasJSONType reports whether a decoded JSON value has the given OpenAPI type.
+func hasJSONType(value interface{}, fieldType string) bool {
+	switch v := value.(type) {
+	case string:
+		return fieldType == "string"
+	case bool:
+		return fieldType == "boolean"
+	case float64:
+		return fieldType == "number" || (fieldType == "integer" && v == math.Trunc(v))
+	case map[string]interface{}:
+		return fieldType == "object"
+	case []interface{}:
+		return fieldType == "array"
+	}
+	return false
+}
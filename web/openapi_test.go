@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestBodyProblems(t *testing.T) {
+	mitigation := routeDocs["POST /api/educational/attacks/:id/mitigation"]
+	createToken := routeDocs["POST /api/educational/demo/token/create"]
+
+	tests := []struct {
+		name string
+		doc  routeDoc
+		body string
+		want string
+	}{
+		{"valid required field", mitigation, `{"enabled":false}`, ""},
+		{"string for boolean", mitigation, `{"enabled":"no"}`, "wrong field types: enabled must be boolean"},
+		{"missing required field", mitigation, `{}`, "missing required fields: enabled"},
+		{"not JSON", mitigation, `enabled`, "missing required fields: enabled"},
+		{"empty body without required fields", createToken, ``, ""},
+		{"valid optional fields", createToken, `{"subject":"alice@example.com","ttl_seconds":600}`, ""},
+		{"fractional integer", createToken, `{"ttl_seconds":1.5}`, "wrong field types: ttl_seconds must be integer"},
+		{"undocumented field", createToken, `{"extra":1}`, ""},
+	}
+	for _, test := range tests {
+		got := strings.Join(requestBodyProblems(test.doc, []byte(test.body)), "; ")
+		if got != test.want {
+			t.Errorf("%s: problems = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestStrictOpenAPIValidationRejectsWrongTypes(t *testing.T) {
+	router := gin.New()
+	router.Use(openAPIValidation(true))
+	router.POST("/api/v1/educational/attacks/:id/mitigation", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for body, want := range map[string]int{
+		`{"enabled":true}`: http.StatusOK,
+		`{"enabled":"no"}`: http.StatusBadRequest,
+	} {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/educational/attacks/csrf/mitigation", strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		if recorder.Code != want {
+			t.Errorf("POST %s = %d, want %d", body, recorder.Code, want)
+		}
+	}
+}
@@ -0,0 +1,470 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Per-visitor sandbox tenants.
+// Every browser (gauth_sandbox cookie) or API client (X-Sandbox-ID header)
+// gets an isolated tenant with seeded users and roles and its own token
+// registry. Sandboxes are torn down after sandboxIdleTimeout of inactivity.
+
+const (
+	sandboxCookie      = "gauth_sandbox"
+	sandboxHeader      = "X-Sandbox-ID"
+	sandboxIdleTimeout = 30 * time.Minute
+	maxSandboxes       = 500
+	maxSandboxEvents   = 200
+)
+
+type SandboxRole struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type SandboxUser struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+}
+
+// SandboxEvent records a learner action, used by the tutorials to verify
+// that a step was actually performed.
+type SandboxEvent struct {
+	Kind    string                 `json:"kind"`
+	At      time.Time              `json:"at"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+type sandbox struct {
+	ID        string
+	CreatedAt time.Time
+	tokens    *tokenService
+	users     map[string]SandboxUser
+	roles     map[string]SandboxRole
+
+	mu       sync.Mutex
+	lastSeen time.Time
+	events   []SandboxEvent
+
+	// mitigationsOff lists attack scenarios whose protection the learner has
+	// disabled; every mitigation is on by default
+	mitigationsOff map[string]bool
+
+	// tutorialRuns holds tutorial progress by tutorial ID. It is advanced as
+	// events are recorded, so it survives events leaving the capped log.
+	tutorialRuns map[string]*tutorialRun
+}
+
+func newSandbox(signingKey []byte) *sandbox {
+	now := time.Now()
+	sb := &sandbox{
+		ID:        "sbx_" + randomHex(12),
+		CreatedAt: now,
+		lastSeen:  now,
+		tokens:    newTokenService(signingKey),
+		users:     map[string]SandboxUser{},
+		roles:     map[string]SandboxRole{},
+
+		mitigationsOff: map[string]bool{},
+		tutorialRuns:   map[string]*tutorialRun{},
+	}
+
+	for _, role := range []SandboxRole{
+		{Name: "admin", Scopes: []string{"read", "write", "demo", "delegate", "admin"}},
+		{Name: "editor", Scopes: []string{"read", "write", "demo"}},
+		{Name: "viewer", Scopes: []string{"read", "demo"}},
+	} {
+		sb.roles[role.Name] = role
+	}
+	for _, user := range []SandboxUser{
+		{Email: demoSubject, Name: "Demo User", Role: "editor"},
+		{Email: "alice@example.com", Name: "Alice (administrator)", Role: "admin"},
+		{Email: "bob@example.com", Name: "Bob (editor)", Role: "editor"},
+		{Email: "carol@example.com", Name: "Carol (viewer)", Role: "viewer"},
+	} {
+		sb.users[user.Email] = user
+	}
+
+	return sb
+}
+
+// Scopes returns the scopes the user's role grants, or false for unknown users.
+func (sb *sandbox) Scopes(email string) ([]string, bool) {
+	user, exists := sb.users[email]
+	if !exists {
+		return nil, false
+	}
+	return sb.roles[user.Role].Scopes, true
+}
+
+// Record appends an activity event, keeping the most recent maxSandboxEvents,
+// and advances tutorial progress.
+func (sb *sandbox) Record(kind string, details map[string]interface{}) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	event := SandboxEvent{Kind: kind, At: time.Now(), Details: details}
+	sb.events = append(sb.events, event)
+	if len(sb.events) > maxSandboxEvents {
+		sb.events = sb.events[len(sb.events)-maxSandboxEvents:]
+	}
+	sb.advanceTutorialsLocked(event)
+}
+
+// Events returns a copy of the activity log, oldest first.
+func (sb *sandbox) Events() []SandboxEvent {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return append([]SandboxEvent{}, sb.events...)
+}
+
+// MitigationEnabled reports whether the protection against an attack
+// scenario is active in this sandbox.
+func (sb *sandbox) MitigationEnabled(attack string) bool {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return !sb.mitigationsOff[attack]
+}
+
+func (sb *sandbox) SetMitigation(attack string, enabled bool) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if enabled {
+		delete(sb.mitigationsOff, attack)
+	} else {
+		sb.mitigationsOff[attack] = true
+	}
+}
+
+func (sb *sandbox) touch() {
+	sb.mu.Lock()
+	sb.lastSeen = time.Now()
+	sb.mu.Unlock()
+}
+
+func (sb *sandbox) LastSeen() time.Time {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.lastSeen
+}
+
+type sandboxManager struct {
+	mu         sync.Mutex
+	signingKey []byte
+	sandboxes  map[string]*sandbox
+}
+
+func newSandboxManager(signingKey []byte) *sandboxManager {
+	return &sandboxManager{
+		signingKey: signingKey,
+		sandboxes:  map[string]*sandbox{},
+	}
+}
+
+// Get returns the live sandbox with the given ID.
+func (m *sandboxManager) Get(id string) (*sandbox, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sb, exists := m.sandboxes[id]
+	return sb, exists
+}
+
+// Create starts a new sandbox, evicting the least recently used one when the
+// limit is reached.
+func (m *sandboxManager) Create() *sandbox {
+	sb := newSandbox(m.signingKey)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.sandboxes) >= maxSandboxes {
+		var oldest *sandbox
+		for _, candidate := range m.sandboxes {
+			if oldest == nil || candidate.LastSeen().Before(oldest.LastSeen()) {
+				oldest = candidate
+			}
+		}
+		delete(m.sandboxes, oldest.ID)
+		slog.Debug("sandbox evicted", "id", oldest.ID)
+	}
+	m.sandboxes[sb.ID] = sb
+	slog.Debug("sandbox created", "id", sb.ID, "active", len(m.sandboxes))
+
+	return sb
+}
+
+func (m *sandboxManager) Delete(id string) {
+	m.mu.Lock()
+	delete(m.sandboxes, id)
+	m.mu.Unlock()
+}
+
+// reapIdle removes sandboxes idle for longer than sandboxIdleTimeout until ctx
+// is cancelled.
+func (m *sandboxManager) reapIdle(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.mu.Lock()
+			for id, sb := range m.sandboxes {
+				if now.Sub(sb.LastSeen()) > sandboxIdleTimeout {
+					delete(m.sandboxes, id)
+					slog.Debug("idle sandbox removed", "id", id)
+				}
+			}
+			m.mu.Unlock()
+		}
+	}
+}
+
+// sandboxSession resolves the caller's sandbox, creating one when a POST
+// carries no known sandbox ID, and echoes the ID back in header and cookie.
+// Cookie-authenticated POSTs from another origin are rejected unless the
+// learner disabled the csrf mitigation.
+func (s *EducationalServer) sandboxSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(sandboxHeader)
+		fromCookie := id == ""
+		if fromCookie {
+			id, _ = c.Cookie(sandboxCookie)
+		}
+
+		sb, exists := s.sandboxes.Get(id)
+		if !exists && c.Request.Method == http.MethodGet {
+			// Reads are answered from a throwaway, unregistered sandbox so that
+			// crawlers and first page views cannot evict learners' sandboxes;
+			// its empty ID tells clients that nothing was stored
+			ephemeral := newSandbox(s.sandboxes.signingKey)
+			ephemeral.ID = ""
+			c.Set("sandbox", ephemeral)
+			c.Next()
+			return
+		}
+		if !exists {
+			sb = s.sandboxes.Create()
+		}
+		sb.touch()
+
+		if exists && fromCookie && c.Request.Method == http.MethodPost &&
+			!sameOrigin(c.Request) && sb.MitigationEnabled("csrf") {
+			c.AbortWithStatusJSON(http.StatusForbidden, DemoResponse{
+				Success:     false,
+				Message:     "Cross-origin request rejected: Origin " + c.GetHeader("Origin") + " does not match this server",
+				Educational: true,
+				Timestamp:   time.Now(),
+			})
+			return
+		}
+
+		c.Header(sandboxHeader, sb.ID)
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(sandboxCookie, sb.ID, int(sandboxIdleTimeout.Seconds()), "/", "", false, true)
+		c.Set("sandbox", sb)
+
+		c.Next()
+	}
+}
+
+// sameOrigin reports whether a request's Origin header, when present, names
+// the host it was sent to.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	return err == nil && parsed.Host == r.Host
+}
+
+func currentSandbox(c *gin.Context) *sandbox {
+	return c.MustGet("sandbox").(*sandbox)
+}
+
+// simulatedKey marks requests that an attack simulation sends on the
+// learner's behalf.
+type simulatedKey struct{}
+
+func markSimulated(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), simulatedKey{}, true))
+}
+
+// recordActivity records an event in the request's sandbox, unless the
+// request was sent by an attack simulation: those runs must not show up as
+// the learner's activity or advance their tutorials.
+func recordActivity(c *gin.Context, kind string, details map[string]interface{}) {
+	if c.Request.Context().Value(simulatedKey{}) != nil {
+		return
+	}
+	currentSandbox(c).Record(kind, details)
+}
+
+func (s *EducationalServer) getSandbox(c *gin.Context) {
+	sb := currentSandbox(c)
+
+	users := []SandboxUser{}
+	for _, user := range sb.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Email < users[j].Email })
+
+	roles := []SandboxRole{}
+	for _, role := range sb.roles {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success: true,
+		Message: "Sandbox retrieved",
+		Data: map[string]interface{}{
+			"id":            sb.ID,
+			"created_at":    sb.CreatedAt.Unix(),
+			"idle_timeout":  sandboxIdleTimeout.String(),
+			"active_tokens": sb.tokens.ActiveCount(),
+			"users":         users,
+			"roles":         roles,
+			"warning":       "Sandbox data lives in memory and is discarded after inactivity",
+		},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) getSandboxActivity(c *gin.Context) {
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Sandbox activity retrieved",
+		Data:        map[string]interface{}{"events": currentSandbox(c).Events()},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) resetSandbox(c *gin.Context) {
+	previous := currentSandbox(c)
+	s.sandboxes.Delete(previous.ID)
+
+	sb := s.sandboxes.Create()
+	c.Header(sandboxHeader, sb.ID)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sandboxCookie, sb.ID, int(sandboxIdleTimeout.Seconds()), "/", "", false, true)
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success: true,
+		Message: "Sandbox reset",
+		Data: map[string]interface{}{
+			"previous_id": previous.ID,
+			"id":          sb.ID,
+		},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+// sandboxConsole executes an educational API call on behalf of the learner
+// against their own sandbox and returns the raw exchange.
+func (s *EducationalServer) sandboxConsole(c *gin.Context) {
+	var request struct {
+		Method string          `json:"method"`
+		Path   string          `json:"path"`
+		Body   json.RawMessage `json:"body"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.Path == "" {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Console requests need a method and a path such as /demo/token/create",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	method := strings.ToUpper(request.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	if method != http.MethodGet && method != http.MethodPost {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Console supports GET and POST only",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	// Calls stay within the educational API of the version serving this request
+	base := strings.TrimSuffix(c.FullPath(), "/sandbox/console")
+	sb := currentSandbox(c)
+
+	// Checked on the parsed and cleaned path, so percent-encoding or extra
+	// slashes cannot smuggle in a nested console call or a reset of the
+	// caller's sandbox
+	inner, err := http.NewRequest(method, base+"/"+strings.TrimLeft(request.Path, "/"), bytes.NewReader(request.Body))
+	target := ""
+	if err == nil {
+		target = strings.TrimPrefix(inner.URL.Path, base)
+	}
+	cleaned := path.Clean("/" + target)
+	if err != nil || strings.Contains(target, "..") ||
+		strings.HasPrefix(cleaned, "/sandbox/console") || strings.HasPrefix(cleaned, "/sandbox/reset") {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Console cannot call " + request.Path,
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	inner.RemoteAddr = c.Request.RemoteAddr
+	inner.Header.Set("Content-Type", "application/json")
+	inner.Header.Set(sandboxHeader, sb.ID)
+	recorder := httptest.NewRecorder()
+
+	started := time.Now()
+	s.router.ServeHTTP(recorder, inner)
+	elapsed := time.Since(started)
+
+	var body interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		body = recorder.Body.String()
+	}
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success: true,
+		Message: "Console request executed",
+		Data: map[string]interface{}{
+			"request": map[string]interface{}{
+				"method": method,
+				"url":    inner.URL.String(),
+			},
+			"response": map[string]interface{}{
+				"status":  recorder.Code,
+				"headers": recorder.Header(),
+				"body":    body,
+			},
+			"duration": elapsed.String(),
+		},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// postJSON builds a JSON POST to the v1 educational API.
+func postJSON(path, body string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/educational"+path, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	return request
+}
+
+func TestSandboxConsoleRejectsEscapes(t *testing.T) {
+	s := NewEducationalServer("")
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"/health", true},
+		{"health", true},
+		{"/sandbox/activity", true},
+		{"/sandbox/console", false},
+		{"/sandbox/%63onsole", false},
+		{"/sandbox/%63%6f%6e%73%6f%6c%65", false},
+		{"/sandbox/reset", false},
+		{"/sandbox/%72eset", false},
+		{"//sandbox/reset", false},
+		{"/sandbox//reset", false},
+		{"/sandbox/reset?x=1", false},
+		{"/sandbox/reset/", false},
+		{"/sandbox/./reset", false},
+		{"/../educational/sandbox/reset", false},
+		{"/%2e%2e/version", false},
+		{"/demo/../sandbox/reset", false},
+	}
+	for _, test := range tests {
+		body, _ := json.Marshal(map[string]string{"method": "POST", "path": test.path})
+		response := serve(t, s, postJSON("/sandbox/console", string(body)))
+
+		want := http.StatusBadRequest
+		if test.allowed {
+			want = http.StatusOK
+		}
+		if response.Code != want {
+			t.Errorf("console %q = %d, want %d: %s", test.path, response.Code, want, response.Body)
+		}
+	}
+}
+
+func TestSandboxRejectsCrossOriginCookiePosts(t *testing.T) {
+	s := NewEducationalServer("")
+
+	created := serve(t, s, postJSON("/demo/token/create", `{}`))
+	id := created.Header().Get(sandboxHeader)
+	if id == "" {
+		t.Fatalf("create token did not assign a sandbox: %d %s", created.Code, created.Body)
+	}
+
+	forge := func(origin string) int {
+		request := postJSON("/demo/token/create", `{}`)
+		request.Header.Set("Origin", origin)
+		request.AddCookie(&http.Cookie{Name: sandboxCookie, Value: id})
+		return serve(t, s, request).Code
+	}
+
+	if code := forge("https://attacker.example"); code != http.StatusForbidden {
+		t.Errorf("cross-origin cookie POST = %d, want %d", code, http.StatusForbidden)
+	}
+	if code := forge("http://example.com"); code != http.StatusOK {
+		t.Errorf("same-origin cookie POST = %d, want %d", code, http.StatusOK)
+	}
+
+	// Requests naming the sandbox in a header cannot be forged by a browser
+	withHeader := postJSON("/demo/token/create", `{}`)
+	withHeader.Header.Set("Origin", "https://attacker.example")
+	withHeader.Header.Set(sandboxHeader, id)
+	if code := serve(t, s, withHeader).Code; code != http.StatusOK {
+		t.Errorf("cross-origin POST with %s header = %d, want %d", sandboxHeader, code, http.StatusOK)
+	}
+
+	disable := postJSON("/attacks/csrf/mitigation", `{"enabled":false}`)
+	disable.Header.Set(sandboxHeader, id)
+	if response := serve(t, s, disable); response.Code != http.StatusOK {
+		t.Fatalf("disable csrf mitigation = %d: %s", response.Code, response.Body)
+	}
+	if code := forge("https://attacker.example"); code != http.StatusOK {
+		t.Errorf("cross-origin cookie POST without mitigation = %d, want %d", code, http.StatusOK)
+	}
+}
@@ -1,10 +1,21 @@
 package main
 
 import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,9 +24,17 @@ import (
 // Educational Demo Server for GAuth RFC-0150 Implementation
 // ⚠️ EDUCATIONAL PURPOSE ONLY - NOT FOR PRODUCTION USE
 
+// assets holds the page templates and static files, embedded so the server
+// does not depend on the working directory it is started from.
+//
+//go:embed templates static
+var assets embed.FS
+
 type EducationalServer struct {
-	router *gin.Engine
-	port   string
+	router    *gin.Engine
+	port      string
+	sandboxes *sandboxManager
+	faults    *faultInjector
 }
 
 type DemoResponse struct {
@@ -34,12 +53,15 @@ func NewEducationalServer(port string) *EducationalServer {
 	
 	// Add educational middleware
 	router.Use(educationalMiddleware())
-	router.Use(gin.Logger())
+	router.Use(gin.LoggerWithConfig(gin.LoggerConfig{Skip: accessLogSkipper}))
 	router.Use(gin.Recovery())
+	router.Use(openAPIValidation(os.Getenv("GAUTH_OPENAPI_STRICT") == "true"))
 	
 	server := &EducationalServer{
-		router: router,
-		port:   port,
+		router:    router,
+		port:      port,
+		sandboxes: newSandboxManager(demoSigningKey(os.Getenv("GAUTH_DEMO_JWT_SECRET"))),
+		faults:    newFaultInjector(),
 	}
 	
 	server.setupRoutes()
@@ -53,14 +75,18 @@ func educationalMiddleware() gin.HandlerFunc {
 		c.Header("X-GAuth-Version", "RFC-0150-Educational")
 		c.Header("X-Warning", "Educational implementation - not for production use")
 		
-		// Add CORS headers for local development
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type")
-		
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+		// Add CORS headers for local development; diagnostics are never
+		// readable cross-origin
+		if !strings.HasPrefix(c.Request.URL.Path, "/debug") {
+			c.Header("Access-Control-Allow-Origin", "*")
+			c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", "Content-Type, Accept-Version, If-None-Match, X-Sandbox-ID")
+			c.Header("Access-Control-Expose-Headers", "ETag, API-Version, Deprecation, Sunset, X-Sandbox-ID")
+			
+			if c.Request.Method == "OPTIONS" {
+				c.AbortWithStatus(204)
+				return
+			}
 		}
 		
 		c.Next()
@@ -69,30 +95,40 @@ func educationalMiddleware() gin.HandlerFunc {
 
 func (s *EducationalServer) setupRoutes() {
 	// Static files
-	s.router.Static("/static", "./web/static")
-	s.router.LoadHTMLGlob("./web/templates/*")
+	static, _ := fs.Sub(assets, "static")
+	s.router.StaticFS("/static", http.FS(static))
+	s.router.SetHTMLTemplate(template.Must(template.ParseFS(assets, "templates/*")))
 	
 	// Main educational interface
 	s.router.GET("/", s.serveIndex)
 	
-	// Educational API endpoints (simulated)
-	api := s.router.Group("/api/v1/educational")
-	{
-		api.GET("/health", s.healthCheck)
-		api.POST("/demo/token/create", s.demoCreateToken)
-		api.POST("/demo/token/validate", s.demoValidateToken)
-		api.POST("/demo/token/revoke", s.demoRevokeToken)
-		api.POST("/demo/authz/check", s.demoAuthzCheck)
-		api.GET("/demo/examples", s.listExamples)
-		api.GET("/demo/architecture", s.getArchitecture)
+	// Educational API endpoints (simulated), mounted once per API version
+	for _, version := range apiVersions {
+		api := s.router.Group("/api/"+version.Name+"/educational", versionHeaders(version), s.faults.inject())
+		s.registerEducationalRoutes(api)
 	}
 	
+	// Unversioned requests are rewritten by negotiateVersion before routing;
+	// only those naming an unknown version reach these routes
+	s.router.GET("/api/educational/*path", s.unsupportedVersion)
+	s.router.POST("/api/educational/*path", s.unsupportedVersion)
+	
 	// Documentation endpoints
 	docs := s.router.Group("/docs")
 	{
 		docs.GET("/", s.serveDocs)
 		docs.GET("/rfc", s.serveRFCInfo)
 	}
+	
+	// Machine-readable API description
+	s.router.GET("/openapi.json", s.serveOpenAPI)
+	s.router.GET("/api/dev/collection", s.serveCollection)
+	
+	// Build information for operators
+	s.router.GET("/api/version", s.serveVersion)
+	
+	// Ops-only runtime diagnostics
+	s.setupDiagnosticsRoutes()
 }
 
 func (s *EducationalServer) serveIndex(c *gin.Context) {
@@ -113,6 +149,7 @@ func (s *EducationalServer) healthCheck(c *gin.Context) {
 		Data: map[string]interface{}{
 			"version":     "RFC-0150-Educational",
 			"environment": "learning",
+			"build":       currentBuildInfo(),
 			"uptime":      time.Since(time.Now()).String(),
 			"warning":     "This is an educational implementation only",
 		},
@@ -121,23 +158,89 @@ func (s *EducationalServer) healthCheck(c *gin.Context) {
 }
 
 func (s *EducationalServer) demoCreateToken(c *gin.Context) {
-	// Simulate token creation for educational purposes
-	time.Sleep(time.Millisecond * 500) // Simulate processing time
+	// Optional overrides; an empty body issues the default demo token
+	var request struct {
+		Subject    string `json:"subject"`
+		Scope      string `json:"scope"`
+		TTLSeconds int    `json:"ttl_seconds"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Invalid request format",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	
+	sb := currentSandbox(c)
+	
+	// Tokens can only be issued to sandbox users, within their role's scopes
+	subject := request.Subject
+	if subject == "" {
+		subject = demoSubject
+	}
+	granted, exists := sb.Scopes(subject)
+	if !exists {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Unknown sandbox user: " + subject,
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	scope := request.Scope
+	if scope == "" {
+		scope = strings.Join(granted, " ")
+	}
+	for _, requested := range strings.Fields(scope) {
+		if !containsString(granted, requested) {
+			c.JSON(http.StatusForbidden, DemoResponse{
+				Success:     false,
+				Message:     "Scope \"" + requested + "\" exceeds the role of " + subject,
+				Educational: true,
+				Timestamp:   time.Now(),
+			})
+			return
+		}
+	}
+	ttl := requestedTTL(request.TTLSeconds)
+	
+	signed, claims, err := sb.tokens.Issue(subject, scope, ttl)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, DemoResponse{
+			Success:     false,
+			Message:     "Token could not be signed",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	
+	recordActivity(c, "token.created", map[string]interface{}{
+		"token_id": claims.ID,
+		"subject":  claims.Subject,
+		"scope":    claims.Scope,
+	})
 	
 	token := map[string]interface{}{
-		"id":        fmt.Sprintf("edu_token_%d", time.Now().Unix()),
+		"id":        claims.ID,
+		"token":     signed,
+		"algorithm": "HS256",
 		"type":      "educational_demo",
-		"issuer":    "gauth-educational-demo",
-		"subject":   "demo-user@example.com",
-		"audience":  "learning-environment",
-		"expiresAt": time.Now().Add(time.Hour).Unix(),
-		"createdAt": time.Now().Unix(),
+		"issuer":    claims.Issuer,
+		"subject":   claims.Subject,
+		"audience":  claims.Audience,
+		"expiresAt": claims.ExpiresAt,
+		"createdAt": claims.IssuedAt,
 		"claims": map[string]interface{}{
-			"scope":       "read write demo",
-			"educational": true,
-			"purpose":     "RFC-0150 demonstration",
+			"scope":       claims.Scope,
+			"educational": claims.Educational,
+			"purpose":     claims.Purpose,
 		},
-		"warning": "Educational token - not cryptographically secure",
+		"warning": "Educational token - signed with an in-memory demo key, not for production use",
 	}
 	
 	response := DemoResponse{
@@ -152,9 +255,6 @@ func (s *EducationalServer) demoCreateToken(c *gin.Context) {
 }
 
 func (s *EducationalServer) demoValidateToken(c *gin.Context) {
-	// Simulate token validation
-	time.Sleep(time.Millisecond * 300)
-	
 	var request map[string]interface{}
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, DemoResponse{
@@ -166,26 +266,54 @@ func (s *EducationalServer) demoValidateToken(c *gin.Context) {
 		return
 	}
 	
-	// Simulate validation logic
-	tokenId, exists := request["token_id"].(string)
-	if !exists || tokenId == "" {
+	// Either the signed token or just its ID can be validated
+	token, _ := request["token"].(string)
+	tokenId, _ := request["token_id"].(string)
+	if token == "" && tokenId == "" {
 		c.JSON(http.StatusBadRequest, DemoResponse{
 			Success:     false,
-			Message:     "Token ID required for validation",
+			Message:     "Token or token ID required for validation",
 			Educational: true,
 			Timestamp:   time.Now(),
 		})
 		return
 	}
 	
+	tokens := currentSandbox(c).tokens
+	
+	var claims TokenClaims
+	var err error
+	checks := []string{"expiry", "revocation"}
+	if token != "" {
+		claims, err = tokens.Parse(token)
+		checks = append([]string{"signature"}, checks...)
+	} else {
+		claims, err = tokens.Lookup(tokenId)
+	}
+	if claims.ID != "" {
+		tokenId = claims.ID
+	}
+	
 	validation := map[string]interface{}{
-		"valid":      true,
-		"token_id":   tokenId,
-		"expires_at": time.Now().Add(time.Hour).Unix(),
-		"claims_verified": []string{"scope", "educational", "purpose"},
-		"warning":    "Educational validation - not production-grade security",
+		"valid":           err == nil,
+		"token_id":        tokenId,
+		"expires_at":      claims.ExpiresAt,
+		"claims_verified": checks,
+		"warning":         "Educational validation - in-memory demo key, not production-grade security",
+	}
+	if err != nil {
+		validation["reason"] = err.Error()
 	}
 	
+	validated := map[string]interface{}{
+		"token_id": tokenId,
+		"valid":    err == nil,
+	}
+	if err != nil {
+		validated["reason"] = err.Error()
+	}
+	recordActivity(c, "token.validated", validated)
+	
 	response := DemoResponse{
 		Success:     true,
 		Message:     "Token validation completed",
@@ -198,9 +326,6 @@ func (s *EducationalServer) demoValidateToken(c *gin.Context) {
 }
 
 func (s *EducationalServer) demoRevokeToken(c *gin.Context) {
-	// Simulate token revocation
-	time.Sleep(time.Millisecond * 400)
-	
 	var request map[string]interface{}
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, DemoResponse{
@@ -212,24 +337,64 @@ func (s *EducationalServer) demoRevokeToken(c *gin.Context) {
 		return
 	}
 	
-	tokenId, exists := request["token_id"].(string)
-	if !exists || tokenId == "" {
+	token, _ := request["token"].(string)
+	tokenId, _ := request["token_id"].(string)
+	if token == "" && tokenId == "" {
 		c.JSON(http.StatusBadRequest, DemoResponse{
 			Success:     false,
-			Message:     "Token ID required for revocation",
+			Message:     "Token or token ID required for revocation",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	
+	tokens := currentSandbox(c).tokens
+	if token != "" {
+		// Any correctly signed token may be revoked, including expired ones and
+		// those already invalidated through their parent; forged ones may not.
+		// Tokens unknown to this sandbox are reported by Revoke below.
+		claims, err := tokens.Parse(token)
+		if errors.Is(err, errTokenMalformed) || errors.Is(err, errTokenAlgorithm) || errors.Is(err, errTokenSignature) {
+			c.JSON(http.StatusBadRequest, DemoResponse{
+				Success:     false,
+				Message:     "Token could not be verified: " + err.Error(),
+				Educational: true,
+				Timestamp:   time.Now(),
+			})
+			return
+		}
+		tokenId = claims.ID
+	}
+	
+	claims, added, err := tokens.Revoke(tokenId)
+	if err != nil {
+		c.JSON(http.StatusNotFound, DemoResponse{
+			Success:     false,
+			Message:     "Token not found: " + err.Error(),
 			Educational: true,
 			Timestamp:   time.Now(),
 		})
 		return
 	}
 	
+	sessionsInvalidated := 0
+	if added && time.Now().Unix() < claims.ExpiresAt {
+		sessionsInvalidated = 1
+	}
+	
+	recordActivity(c, "token.revoked", map[string]interface{}{
+		"token_id":        tokenId,
+		"blacklist_added": added,
+	})
+	
 	revocation := map[string]interface{}{
-		"revoked":           true,
-		"token_id":          tokenId,
-		"revocation_time":   time.Now().Unix(),
-		"blacklist_added":   true,
-		"sessions_invalidated": 1,
-		"warning":           "Educational revocation - not persistent across restarts",
+		"revoked":              true,
+		"token_id":             tokenId,
+		"revocation_time":      time.Now().Unix(),
+		"blacklist_added":      added,
+		"sessions_invalidated": sessionsInvalidated,
+		"warning":              "Educational revocation - not persistent across restarts",
 	}
 	
 	response := DemoResponse{
@@ -243,10 +408,121 @@ func (s *EducationalServer) demoRevokeToken(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-func (s *EducationalServer) demoAuthzCheck(c *gin.Context) {
-	// Simulate authorization check
-	time.Sleep(time.Millisecond * 350)
+func (s *EducationalServer) demoDelegateToken(c *gin.Context) {
+	var request struct {
+		Token      string `json:"token"`
+		DelegateTo string `json:"delegate_to"`
+		Scope      string `json:"scope"`
+		TTLSeconds int    `json:"ttl_seconds"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.Token == "" || request.DelegateTo == "" {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Token and delegate_to required for delegation",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	
+	sb := currentSandbox(c)
+	parent, err := sb.tokens.Parse(request.Token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, DemoResponse{
+			Success:     false,
+			Message:     "Token rejected: " + err.Error(),
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	
+	// The grantor needs the delegate scope and can only pass on what it holds
+	granted := parent.Scopes()
+	if !containsString(granted, "delegate") {
+		c.JSON(http.StatusForbidden, DemoResponse{
+			Success:     false,
+			Message:     "Token lacks the \"delegate\" scope",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	if _, exists := sb.users[request.DelegateTo]; !exists {
+		c.JSON(http.StatusBadRequest, DemoResponse{
+			Success:     false,
+			Message:     "Unknown sandbox user: " + request.DelegateTo,
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	
+	scope := request.Scope
+	if scope == "" {
+		// Sub-delegation must be granted explicitly
+		passed := []string{}
+		for _, candidate := range granted {
+			if candidate != "delegate" {
+				passed = append(passed, candidate)
+			}
+		}
+		scope = strings.Join(passed, " ")
+	}
+	for _, requested := range strings.Fields(scope) {
+		if !containsString(granted, requested) {
+			c.JSON(http.StatusForbidden, DemoResponse{
+				Success:     false,
+				Message:     "Scope \"" + requested + "\" exceeds the delegating token",
+				Educational: true,
+				Timestamp:   time.Now(),
+			})
+			return
+		}
+	}
+	
+	ttl := requestedTTL(request.TTLSeconds)
 	
+	signed, claims, err := sb.tokens.Delegate(parent, request.DelegateTo, scope, ttl)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, DemoResponse{
+			Success:     false,
+			Message:     "Token could not be signed",
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+	
+	recordActivity(c, "token.delegated", map[string]interface{}{
+		"token_id":  claims.ID,
+		"parent_id": parent.ID,
+		"from":      parent.Subject,
+		"to":        claims.Subject,
+		"scope":     claims.Scope,
+	})
+	
+	delegation := map[string]interface{}{
+		"id":               claims.ID,
+		"token":            signed,
+		"parent_id":        parent.ID,
+		"subject":          claims.Subject,
+		"scope":            claims.Scope,
+		"delegation_chain": append(append([]string{}, claims.Chain...), claims.Subject),
+		"expiresAt":        claims.ExpiresAt,
+		"warning":          "Educational delegation - revoking the parent token also invalidates this one",
+	}
+	
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Power of attorney delegated",
+		Data:        delegation,
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) demoAuthzCheck(c *gin.Context) {
 	var request map[string]interface{}
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, DemoResponse{
@@ -260,24 +536,47 @@ func (s *EducationalServer) demoAuthzCheck(c *gin.Context) {
 	
 	action, _ := request["action"].(string)
 	resource, _ := request["resource"].(string)
+	token, _ := request["token"].(string)
+	
+	// Without a token the check runs as the anonymous principal
+	principal := anonymousPrincipal
+	session := "anonymous-session"
+	if token != "" {
+		claims, err := currentSandbox(c).tokens.Parse(token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, DemoResponse{
+				Success:     false,
+				Message:     "Token rejected: " + err.Error(),
+				Data:        map[string]interface{}{"token_id": claims.ID},
+				Educational: true,
+				Timestamp:   time.Now(),
+			})
+			return
+		}
+		principal = claims
+		session = claims.ID
+	}
 	
-	// Simulate authorization decision
-	allowed := action == "read" || action == "demo"
-	policy := "educational_demo_policy"
+	decision := evaluateAuthz(principal, action, resource)
 	
-	if action == "admin" {
-		allowed = false
-		policy = "deny_admin_in_demo"
-	}
+	recordActivity(c, "authz.checked", map[string]interface{}{
+		"subject":  principal.Subject,
+		"action":   action,
+		"resource": resource,
+		"allowed":  decision.Allowed,
+		"policy":   decision.Policy,
+	})
 	
 	authz := map[string]interface{}{
-		"allowed":          allowed,
+		"allowed":          decision.Allowed,
 		"action":           action,
 		"resource":         resource,
-		"policy":           policy,
-		"delegation_chain": []string{"user", "demo-session"},
+		"policy":           decision.Policy,
+		"reason":           decision.Reason,
+		"subject":          principal.Subject,
+		"delegation_chain": append(append([]string{}, principal.Chain...), principal.Subject, session),
 		"evaluation_time":  time.Now().Unix(),
-		"warning":          "Educational authorization - simplified logic for demonstration",
+		"warning":          "Educational authorization - simplified policies for demonstration",
 	}
 	
 	response := DemoResponse{
@@ -326,7 +625,7 @@ func (s *EducationalServer) listExamples(c *gin.Context) {
 		Timestamp:   time.Now(),
 	}
 	
-	c.JSON(http.StatusOK, response)
+	respondCacheable(c, response.Data, response)
 }
 
 func (s *EducationalServer) getArchitecture(c *gin.Context) {
@@ -363,7 +662,7 @@ func (s *EducationalServer) getArchitecture(c *gin.Context) {
 		Timestamp:   time.Now(),
 	}
 	
-	c.JSON(http.StatusOK, response)
+	respondCacheable(c, response.Data, response)
 }
 
 func (s *EducationalServer) serveDocs(c *gin.Context) {
@@ -385,7 +684,7 @@ func (s *EducationalServer) serveDocs(c *gin.Context) {
 		},
 	}
 	
-	c.JSON(http.StatusOK, docs)
+	respondCacheable(c, docs, docs)
 }
 
 func (s *EducationalServer) serveRFCInfo(c *gin.Context) {
@@ -414,7 +713,7 @@ func (s *EducationalServer) serveRFCInfo(c *gin.Context) {
 		"production_note":  "These implementations are for learning and should not be used in production environments",
 	}
 	
-	c.JSON(http.StatusOK, rfcInfo)
+	respondCacheable(c, rfcInfo, rfcInfo)
 }
 
 func (s *EducationalServer) Start() error {
@@ -424,12 +723,67 @@ func (s *EducationalServer) Start() error {
 	fmt.Printf("🌐 Server starting on: http://localhost%s\n", s.port)
 	fmt.Printf("📖 Documentation: http://localhost%s/docs/\n", s.port)
 	fmt.Printf("🔧 Health Check: http://localhost%s/api/v1/educational/health\n", s.port)
+	fmt.Printf("🧾 OpenAPI: http://localhost%s/openapi.json\n", s.port)
 	fmt.Printf("\nPress Ctrl+C to stop the educational demo server\n\n")
 	
-	return s.router.Run(s.port)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	
+	return s.Run(ctx)
+}
+
+// Handler returns the configured router wrapped in version negotiation, as
+// served by Run, so tests can drive it directly with httptest.
+func (s *EducationalServer) Handler() http.Handler {
+	return negotiateVersion(s.router)
+}
+
+// Run serves until ctx is cancelled, then shuts down gracefully, giving
+// in-flight requests a few seconds to complete.
+func (s *EducationalServer) Run(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:    s.port,
+		Handler: s.Handler(),
+	}
+	
+	go s.sandboxes.reapIdle(ctx, time.Minute)
+	
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.ListenAndServe()
+	}()
+	
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	
+	log.Printf("🛑 Shutting down educational demo server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	
+	return httpServer.Shutdown(shutdownCtx)
 }
 
 func main() {
+	// Route slog (and the standard logger, which slog.SetDefault redirects)
+	// through a handler gated by the runtime-adjustable level; every record
+	// carries the build it came from
+	build := currentBuildInfo()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})).
+		With("version", build.Version, "commit", build.Commit, "build_date", build.BuildDate))
+	
+	// "openapi" prints the generated API document instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "openapi" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(NewEducationalServer("").openAPISpec()); err != nil {
+			log.Fatalf("❌ Failed to write OpenAPI document: %v", err)
+		}
+		return
+	}
+	
 	// Educational demo server configuration
 	port := ":8080"
 	if len(os.Args) > 1 {
@@ -442,6 +796,7 @@ func main() {
 	// Add educational startup message
 	log.Printf("🎓 Starting GAuth Educational Demo Server")
 	log.Printf("⚠️ Educational Implementation - Not for Production Use")
+	log.Printf("🏷️ Version %s (commit %s, built %s, %s)", build.Version, build.Commit, build.BuildDate, build.GoVersion)
 	
 	if err := server.Start(); err != nil {
 		log.Fatalf("❌ Failed to start educational demo server: %v", err)
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serve sends a request through the server's handler and returns the response.
+func serve(t *testing.T, s *EducationalServer, r *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	s.Handler().ServeHTTP(recorder, r)
+	return recorder
+}
+
+func TestHandlerServesPagesAndAPI(t *testing.T) {
+	s := NewEducationalServer("")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/", "<html"},
+		{"/static/css/style.css", "{"},
+		{"/api/v1/educational/health", `"educational":true`},
+	}
+	for _, test := range tests {
+		response := serve(t, s, httptest.NewRequest(http.MethodGet, test.path, nil))
+		if response.Code != http.StatusOK {
+			t.Errorf("GET %s = %d, want %d", test.path, response.Code, http.StatusOK)
+			continue
+		}
+		if !strings.Contains(response.Body.String(), test.want) {
+			t.Errorf("GET %s body does not contain %q", test.path, test.want)
+		}
+	}
+}
+
+func TestHandlerIssuesTokenInNewSandbox(t *testing.T) {
+	s := NewEducationalServer("")
+
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/educational/demo/token/create", strings.NewReader(`{}`))
+	request.Header.Set("Content-Type", "application/json")
+	response := serve(t, s, request)
+	if response.Code != http.StatusOK {
+		t.Fatalf("create token = %d, want %d: %s", response.Code, http.StatusOK, response.Body)
+	}
+	if response.Header().Get(sandboxHeader) == "" {
+		t.Errorf("create token response has no %s header", sandboxHeader)
+	}
+
+	var body DemoResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &body); err != nil || !body.Success {
+		t.Errorf("create token body = %s, want a successful DemoResponse", response.Body)
+	}
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// In-memory token service for the educational demo.
+// Tokens are real HS256 JWTs and revocation is enforced, but the registry and
+// denylist live in process memory (one service per sandbox) and disappear on
+// restart. Refresh tokens are opaque and grouped into families, one per
+// login, so reuse of a rotated one can revoke the whole family.
+
+const (
+	demoIssuer   = "gauth-educational-demo"
+	demoAudience = "learning-environment"
+	demoSubject  = "demo-user@example.com"
+	demoTokenTTL = time.Hour
+	maxTokenTTL  = 24 * time.Hour
+)
+
+var (
+	errTokenMalformed = errors.New("token is malformed")
+	errTokenAlgorithm = errors.New("token algorithm is not HS256")
+	errTokenSignature = errors.New("token signature is invalid")
+	errTokenExpired   = errors.New("token has expired")
+	errTokenRevoked   = errors.New("token has been revoked")
+	errTokenUnknown   = errors.New("token was not issued by this server")
+	errParentRevoked  = errors.New("delegating token has been revoked")
+	errRefreshReused  = errors.New("refresh token was already used; its token family has been revoked")
+	errFamilyRevoked  = errors.New("refresh token family has been revoked")
+)
+
+type TokenClaims struct {
+	ID          string `json:"jti"`
+	Issuer      string `json:"iss"`
+	Subject     string `json:"sub"`
+	Audience    string `json:"aud"`
+	IssuedAt    int64  `json:"iat"`
+	ExpiresAt   int64  `json:"exp"`
+	Scope       string `json:"scope"`
+	Educational bool   `json:"educational"`
+	Purpose     string `json:"purpose"`
+
+	// Power-of-attorney delegation: the token this one was derived from and
+	// the subjects that delegated authority, root first
+	ParentID string   `json:"parent_jti,omitempty"`
+	Chain    []string `json:"delegation_chain,omitempty"`
+}
+
+// Scopes returns the space-separated scope claim as a list.
+func (t TokenClaims) Scopes() []string {
+	return strings.Fields(t.Scope)
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+// refreshToken is an opaque refresh token; every token rotated from the same
+// login shares its family.
+type refreshToken struct {
+	Family    string
+	Subject   string
+	Scope     string
+	ExpiresAt int64
+	Used      bool
+}
+
+type tokenService struct {
+	mu      sync.Mutex
+	secret  []byte
+	issued  map[string]TokenClaims
+	revoked map[string]time.Time
+
+	refresh         map[string]*refreshToken
+	revokedFamilies map[string]bool
+}
+
+func newTokenService(signingKey []byte) *tokenService {
+	return &tokenService{
+		secret:          signingKey,
+		issued:          map[string]TokenClaims{},
+		revoked:         map[string]time.Time{},
+		refresh:         map[string]*refreshToken{},
+		revokedFamilies: map[string]bool{},
+	}
+}
+
+// demoSigningKey returns secret as key material, or a random per-process key
+// when secret is empty.
+func demoSigningKey(secret string) []byte {
+	if secret != "" {
+		return []byte(secret)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic("educational demo: cannot generate signing key: " + err.Error())
+	}
+	return key
+}
+
+// requestedTTL converts a client-supplied lifetime in seconds, falling back to
+// demoTokenTTL when it is missing and clamping it to maxTokenTTL. The bound is
+// checked before converting so huge values cannot overflow time.Duration.
+func requestedTTL(seconds int) time.Duration {
+	switch {
+	case seconds <= 0:
+		return demoTokenTTL
+	case int64(seconds) >= int64(maxTokenTTL/time.Second):
+		return maxTokenTTL
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Issue signs a new token and records it as an active session.
+func (t *tokenService) Issue(subject, scope string, ttl time.Duration) (string, TokenClaims, error) {
+	now := time.Now()
+	return t.issue(TokenClaims{
+		Subject:   subject,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		Scope:     scope,
+	})
+}
+
+// Delegate derives a token for subject from parent. The delegated token never
+// outlives its parent and is invalidated when the parent is revoked.
+func (t *tokenService) Delegate(parent TokenClaims, subject, scope string, ttl time.Duration) (string, TokenClaims, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl).Unix()
+	if expiresAt > parent.ExpiresAt {
+		expiresAt = parent.ExpiresAt
+	}
+
+	return t.issue(TokenClaims{
+		Subject:   subject,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt,
+		Scope:     scope,
+		ParentID:  parent.ID,
+		Chain:     append(append([]string{}, parent.Chain...), parent.Subject),
+	})
+}
+
+func (t *tokenService) issue(claims TokenClaims) (string, TokenClaims, error) {
+	claims.ID = "edu_token_" + randomHex(8)
+	claims.Issuer = demoIssuer
+	claims.Audience = demoAudience
+	claims.Educational = true
+	claims.Purpose = "RFC-0150 demonstration"
+
+	token, err := t.sign(claims)
+	if err != nil {
+		return "", TokenClaims{}, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pruneLocked(time.Now())
+	t.issued[claims.ID] = claims
+
+	return token, claims, nil
+}
+
+// IssueRefresh starts a new refresh token family for subject.
+func (t *tokenService) IssueRefresh(subject, scope string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.issueRefreshLocked("fam_"+randomHex(8), subject, scope)
+}
+
+func (t *tokenService) issueRefreshLocked(family, subject, scope string) string {
+	token := "rt_" + randomHex(16)
+	t.refresh[token] = &refreshToken{
+		Family:    family,
+		Subject:   subject,
+		Scope:     scope,
+		ExpiresAt: time.Now().Add(maxTokenTTL).Unix(),
+	}
+	return token
+}
+
+// Refresh redeems a refresh token for a new access token. With rotate set,
+// each refresh token is single use: redeeming returns its successor in the
+// same family, and presenting a spent one revokes the whole family. Without
+// rotation the same refresh token is returned and can be redeemed again.
+func (t *tokenService) Refresh(token string, rotate bool) (access string, claims TokenClaims, next string, err error) {
+	t.mu.Lock()
+	current, exists := t.refresh[token]
+	switch {
+	case !exists:
+		err = errTokenUnknown
+	case t.revokedFamilies[current.Family]:
+		err = errFamilyRevoked
+	case time.Now().Unix() >= current.ExpiresAt:
+		err = errTokenExpired
+	case rotate && current.Used:
+		t.revokedFamilies[current.Family] = true
+		err = errRefreshReused
+	}
+	if err != nil {
+		t.mu.Unlock()
+		return "", TokenClaims{}, "", err
+	}
+
+	next = token
+	if rotate {
+		current.Used = true
+		next = t.issueRefreshLocked(current.Family, current.Subject, current.Scope)
+	}
+	subject, scope := current.Subject, current.Scope
+	t.mu.Unlock()
+
+	access, claims, err = t.Issue(subject, scope, demoTokenTTL)
+	if err != nil {
+		return "", TokenClaims{}, "", err
+	}
+	return access, claims, next, nil
+}
+
+// Parse verifies the signature, expiry and revocation status of a compact JWT.
+// The decoded claims are returned alongside signature and expiry errors so
+// callers can still show what the token contained.
+func (t *tokenService) Parse(token string) (TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return TokenClaims{}, errTokenMalformed
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return TokenClaims{}, errTokenMalformed
+	}
+	var claims TokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return TokenClaims{}, errTokenMalformed
+	}
+
+	// Only HS256 is accepted; in particular "none" must never be trusted
+	if header.Algorithm != "HS256" {
+		return claims, errTokenAlgorithm
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, t.mac(parts[0]+"."+parts[1])) {
+		return claims, errTokenSignature
+	}
+
+	return claims, t.checkStatus(claims)
+}
+
+// Lookup returns the claims of an issued token by its ID.
+func (t *tokenService) Lookup(id string) (TokenClaims, error) {
+	t.mu.Lock()
+	claims, exists := t.issued[id]
+	t.mu.Unlock()
+
+	if !exists {
+		return TokenClaims{}, errTokenUnknown
+	}
+	return claims, t.checkStatus(claims)
+}
+
+// Revoke adds the token ID to the denylist. added is false when the token
+// was already revoked.
+func (t *tokenService) Revoke(id string) (claims TokenClaims, added bool, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	claims, exists := t.issued[id]
+	if !exists {
+		return TokenClaims{}, false, errTokenUnknown
+	}
+	if _, revoked := t.revoked[id]; revoked {
+		return claims, false, nil
+	}
+
+	t.revoked[id] = time.Now()
+	return claims, true, nil
+}
+
+// ActiveCount returns the number of issued tokens that are neither revoked
+// nor expired.
+func (t *tokenService) ActiveCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now().Unix()
+	active := 0
+	for id, claims := range t.issued {
+		if _, revoked := t.revoked[id]; !revoked && now < claims.ExpiresAt {
+			active++
+		}
+	}
+	return active
+}
+
+// checkStatus rejects tokens this service did not issue (including tokens
+// from other sandboxes signed with the same key), revoked and expired ones,
+// and delegated tokens whose parent has been revoked.
+func (t *tokenService) checkStatus(claims TokenClaims) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, issued := t.issued[claims.ID]; !issued {
+		return errTokenUnknown
+	}
+	if _, revoked := t.revoked[claims.ID]; revoked {
+		return errTokenRevoked
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return errTokenExpired
+	}
+
+	for parentID := claims.ParentID; parentID != ""; parentID = t.issued[parentID].ParentID {
+		if _, revoked := t.revoked[parentID]; revoked {
+			return errParentRevoked
+		}
+	}
+	return nil
+}
+
+func (t *tokenService) sign(claims TokenClaims) (string, error) {
+	header, err := json.Marshal(tokenHeader{Algorithm: "HS256", Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(t.mac(signingInput)), nil
+}
+
+func (t *tokenService) mac(signingInput string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+// pruneLocked forgets tokens that expired over a TTL ago so long-running
+// demos do not grow without bound. t.mu must be held.
+func (t *tokenService) pruneLocked(now time.Time) {
+	cutoff := now.Add(-demoTokenTTL).Unix()
+	for id, claims := range t.issued {
+		if claims.ExpiresAt < cutoff {
+			delete(t.issued, id)
+			delete(t.revoked, id)
+		}
+	}
+	for token, refresh := range t.refresh {
+		if refresh.ExpiresAt < now.Unix() {
+			delete(t.refresh, token)
+		}
+	}
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic("educational demo: cannot read random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
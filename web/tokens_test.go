@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestTokenService() *tokenService {
+	return newTokenService([]byte("test-signing-key"))
+}
+
+func TestParseAcceptsIssuedToken(t *testing.T) {
+	tokens := newTestTokenService()
+	signed, issued, err := tokens.Issue(demoSubject, "read demo", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	claims, err := tokens.Parse(signed)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if claims.ID != issued.ID || claims.Subject != demoSubject || claims.Scope != "read demo" {
+		t.Errorf("Parse returned %+v, want claims of %+v", claims, issued)
+	}
+}
+
+func TestParseRejectsTamperedToken(t *testing.T) {
+	tokens := newTestTokenService()
+	signed, claims, err := tokens.Issue(demoSubject, "read", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	parts := strings.Split(signed, ".")
+
+	// Escalated claims under the original signature
+	claims.Scope = "read write admin"
+	payload, _ := json.Marshal(claims)
+	escalated := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+	if _, err := tokens.Parse(escalated); !errors.Is(err, errTokenSignature) {
+		t.Errorf("Parse(modified claims) = %v, want %v", err, errTokenSignature)
+	}
+
+	// Signature made with a different key
+	forged, _, err := newTokenService([]byte("other-key")).Issue(demoSubject, "read", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if _, err := tokens.Parse(forged); !errors.Is(err, errTokenSignature) {
+		t.Errorf("Parse(foreign signature) = %v, want %v", err, errTokenSignature)
+	}
+
+	if _, err := tokens.Parse(parts[0] + "." + parts[1]); !errors.Is(err, errTokenMalformed) {
+		t.Errorf("Parse(two segments) = %v, want %v", err, errTokenMalformed)
+	}
+}
+
+func TestParseRejectsAlgNone(t *testing.T) {
+	tokens := newTestTokenService()
+	_, claims, err := tokens.Issue(demoSubject, "read", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	header, _ := json.Marshal(tokenHeader{Algorithm: "none", Type: "JWT"})
+	payload, _ := json.Marshal(claims)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+
+	if _, err := tokens.Parse(unsigned); !errors.Is(err, errTokenAlgorithm) {
+		t.Errorf("Parse(alg=none) = %v, want %v", err, errTokenAlgorithm)
+	}
+}
+
+func TestParseRejectsExpiredToken(t *testing.T) {
+	tokens := newTestTokenService()
+	signed, _, err := tokens.Issue(demoSubject, "read", -time.Minute)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if _, err := tokens.Parse(signed); !errors.Is(err, errTokenExpired) {
+		t.Errorf("Parse(expired) = %v, want %v", err, errTokenExpired)
+	}
+}
+
+func TestRevokeDenylistsToken(t *testing.T) {
+	tokens := newTestTokenService()
+	signed, claims, err := tokens.Issue(demoSubject, "read", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if _, added, err := tokens.Revoke(claims.ID); err != nil || !added {
+		t.Fatalf("Revoke = (added %v, %v), want (true, nil)", added, err)
+	}
+	if _, added, err := tokens.Revoke(claims.ID); err != nil || added {
+		t.Errorf("second Revoke = (added %v, %v), want (false, nil)", added, err)
+	}
+	if _, err := tokens.Parse(signed); !errors.Is(err, errTokenRevoked) {
+		t.Errorf("Parse(revoked) = %v, want %v", err, errTokenRevoked)
+	}
+	if _, err := tokens.Lookup(claims.ID); !errors.Is(err, errTokenRevoked) {
+		t.Errorf("Lookup(revoked) = %v, want %v", err, errTokenRevoked)
+	}
+	if _, _, err := tokens.Revoke("edu_token_unknown"); !errors.Is(err, errTokenUnknown) {
+		t.Errorf("Revoke(unknown) = %v, want %v", err, errTokenUnknown)
+	}
+}
+
+func TestRevokingParentInvalidatesDelegatedTokens(t *testing.T) {
+	tokens := newTestTokenService()
+	_, root, err := tokens.Issue("alice@example.com", "read write delegate", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	_, child, err := tokens.Delegate(root, "bob@example.com", "read delegate", time.Hour)
+	if err != nil {
+		t.Fatalf("Delegate: %v", err)
+	}
+	grandchildToken, grandchild, err := tokens.Delegate(child, "carol@example.com", "read", time.Hour)
+	if err != nil {
+		t.Fatalf("Delegate: %v", err)
+	}
+
+	if got := strings.Join(grandchild.Chain, ","); got != "alice@example.com,bob@example.com" {
+		t.Errorf("delegation chain = %q, want alice then bob", got)
+	}
+	if _, err := tokens.Parse(grandchildToken); err != nil {
+		t.Fatalf("Parse(delegated) before revocation: %v", err)
+	}
+
+	if _, _, err := tokens.Revoke(root.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if _, err := tokens.Parse(grandchildToken); !errors.Is(err, errParentRevoked) {
+		t.Errorf("Parse(delegated) after root revocation = %v, want %v", err, errParentRevoked)
+	}
+}
+
+func TestDelegatedTokenNeverOutlivesParent(t *testing.T) {
+	tokens := newTestTokenService()
+	_, parent, err := tokens.Issue("alice@example.com", "read delegate", time.Minute)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	_, child, err := tokens.Delegate(parent, "bob@example.com", "read", time.Hour)
+	if err != nil {
+		t.Fatalf("Delegate: %v", err)
+	}
+
+	if child.ExpiresAt > parent.ExpiresAt {
+		t.Errorf("delegated token expires at %d, after its parent at %d", child.ExpiresAt, parent.ExpiresAt)
+	}
+}
+
+func TestParseRejectsTokenFromAnotherSandbox(t *testing.T) {
+	key := []byte("shared-key")
+	signed, _, err := newTokenService(key).Issue(demoSubject, "read", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if _, err := newTokenService(key).Parse(signed); !errors.Is(err, errTokenUnknown) {
+		t.Errorf("Parse(token from another service) = %v, want %v", err, errTokenUnknown)
+	}
+}
+
+func TestRequestedTTL(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    time.Duration
+	}{
+		{0, demoTokenTTL},
+		{-5, demoTokenTTL},
+		{90, 90 * time.Second},
+		{86399, 86399 * time.Second},
+		{86400, maxTokenTTL},
+		{86401, maxTokenTTL},
+		{9300000000, maxTokenTTL},
+	}
+	for _, test := range tests {
+		if got := requestedTTL(test.seconds); got != test.want {
+			t.Errorf("requestedTTL(%d) = %v, want %v", test.seconds, got, test.want)
+		}
+	}
+}
+
+func TestRefreshRotationRevokesFamilyOnReuse(t *testing.T) {
+	tokens := newTestTokenService()
+	first := tokens.IssueRefresh(demoSubject, "read")
+
+	access, claims, second, err := tokens.Refresh(first, true)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if second == first {
+		t.Errorf("Refresh with rotation returned the same refresh token")
+	}
+	if claims.Subject != demoSubject || claims.Scope != "read" {
+		t.Errorf("refreshed claims %+v, want subject %q with scope read", claims, demoSubject)
+	}
+	if _, err := tokens.Parse(access); err != nil {
+		t.Errorf("Parse(refreshed access token): %v", err)
+	}
+
+	if _, _, _, err := tokens.Refresh(first, true); !errors.Is(err, errRefreshReused) {
+		t.Errorf("Refresh(spent token) = %v, want %v", err, errRefreshReused)
+	}
+	if _, _, _, err := tokens.Refresh(second, true); !errors.Is(err, errFamilyRevoked) {
+		t.Errorf("Refresh(successor after reuse) = %v, want %v", err, errFamilyRevoked)
+	}
+	if _, _, _, err := tokens.Refresh("rt_unknown", true); !errors.Is(err, errTokenUnknown) {
+		t.Errorf("Refresh(unknown) = %v, want %v", err, errTokenUnknown)
+	}
+}
+
+func TestRefreshWithoutRotationAllowsReuse(t *testing.T) {
+	tokens := newTestTokenService()
+	refresh := tokens.IssueRefresh(demoSubject, "read")
+
+	for i := 0; i < 2; i++ {
+		_, _, next, err := tokens.Refresh(refresh, false)
+		if err != nil {
+			t.Fatalf("Refresh #%d: %v", i+1, err)
+		}
+		if next != refresh {
+			t.Errorf("Refresh #%d returned %q, want the same refresh token", i+1, next)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Guided tutorials.
+// Each lesson is a sequence of steps; a step is complete once the learner's
+// sandbox records a matching event after the previous step was completed, so
+// progress reflects what actually happened.
+
+type TutorialStep struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Instruction string `json:"instruction"`
+	Endpoint    string `json:"endpoint"`
+
+	// completes reports whether an activity event satisfies this step, given
+	// the values remembered from earlier steps
+	completes func(event SandboxEvent, remembered map[string]interface{}) bool
+	// remember names event details to keep for later steps, e.g. the ID of
+	// the token a step created
+	remember map[string]string
+}
+
+type Tutorial struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Steps       []TutorialStep `json:"steps"`
+}
+
+type StepProgress struct {
+	TutorialStep
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+var tutorials = []Tutorial{
+	{
+		ID:          "token-lifecycle",
+		Title:       "Token lifecycle and delegation",
+		Description: "Issue a token, validate it, delegate power of attorney and revoke it again",
+		Steps: []TutorialStep{
+			{
+				ID:          "create",
+				Title:       "Create a token",
+				Instruction: "Issue a token for alice@example.com, who holds the delegate scope",
+				Endpoint:    "POST /demo/token/create",
+				completes:   eventIs("token.created", map[string]interface{}{"subject": "alice@example.com"}),
+				remember:    map[string]string{"token_id": "alice_token"},
+			},
+			{
+				ID:          "validate",
+				Title:       "Validate the token",
+				Instruction: "Send alice's signed token to the validation endpoint and confirm it is valid",
+				Endpoint:    "POST /demo/token/validate",
+				completes: eventIs("token.validated", map[string]interface{}{
+					"token_id": fromStep("alice_token"), "valid": true,
+				}),
+			},
+			{
+				ID:          "delegate",
+				Title:       "Delegate power of attorney",
+				Instruction: "Use alice's token to delegate the read scope to bob@example.com",
+				Endpoint:    "POST /demo/token/delegate",
+				completes: eventIs("token.delegated", map[string]interface{}{
+					"parent_id": fromStep("alice_token"), "to": "bob@example.com", "scope": "read",
+				}),
+				remember: map[string]string{"token_id": "bob_token"},
+			},
+			{
+				ID:          "revoke",
+				Title:       "Revoke the token",
+				Instruction: "Revoke alice's token",
+				Endpoint:    "POST /demo/token/revoke",
+				completes: eventIs("token.revoked", map[string]interface{}{
+					"token_id": fromStep("alice_token"), "blacklist_added": true,
+				}),
+			},
+			{
+				ID:          "cascade",
+				Title:       "See the delegation end",
+				Instruction: "Validate bob's delegated token again and see it rejected because alice's token was revoked",
+				Endpoint:    "POST /demo/token/validate",
+				completes: eventIs("token.validated", map[string]interface{}{
+					"token_id": fromStep("bob_token"), "valid": false, "reason": errParentRevoked.Error(),
+				}),
+			},
+		},
+	},
+	{
+		ID:          "authorization-basics",
+		Title:       "Authorization decisions",
+		Description: "See how token scopes and policies decide what a principal may do",
+		Steps: []TutorialStep{
+			{
+				ID:          "viewer-token",
+				Title:       "Act as a viewer",
+				Instruction: "Create a token for carol@example.com, whose viewer role grants read and demo",
+				Endpoint:    "POST /demo/token/create",
+				completes:   eventIs("token.created", map[string]interface{}{"subject": "carol@example.com"}),
+			},
+			{
+				ID:          "allowed-read",
+				Title:       "An allowed action",
+				Instruction: "Check the read action with carol's token",
+				Endpoint:    "POST /demo/authz/check",
+				completes: eventIs("authz.checked", map[string]interface{}{
+					"subject": "carol@example.com", "action": "read", "allowed": true,
+				}),
+			},
+			{
+				ID:          "denied-write",
+				Title:       "A denied action",
+				Instruction: "Check the write action with carol's token and read the denial reason",
+				Endpoint:    "POST /demo/authz/check",
+				completes: eventIs("authz.checked", map[string]interface{}{
+					"subject": "carol@example.com", "action": "write", "allowed": false,
+				}),
+			},
+			{
+				ID:          "admin-policy",
+				Title:       "A policy that overrides scopes",
+				Instruction: "Check the admin action with a token for alice@example.com and see which policy decides",
+				Endpoint:    "POST /demo/authz/check",
+				completes: eventIs("authz.checked", map[string]interface{}{
+					"subject": "alice@example.com", "action": "admin", "policy": "deny_admin_in_demo",
+				}),
+			},
+		},
+	},
+}
+
+// fromStep stands for a value remembered by an earlier step.
+type fromStep string
+
+// eventIs matches events of the given kind whose details contain every
+// key/value pair in want, resolving fromStep values first.
+func eventIs(kind string, want map[string]interface{}) func(SandboxEvent, map[string]interface{}) bool {
+	return func(event SandboxEvent, remembered map[string]interface{}) bool {
+		if event.Kind != kind {
+			return false
+		}
+		for key, value := range want {
+			if name, ok := value.(fromStep); ok {
+				value, ok = remembered[string(name)]
+				if !ok {
+					return false
+				}
+			}
+			if event.Details[key] != value {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func findTutorial(id string) (Tutorial, bool) {
+	for _, tutorial := range tutorials {
+		if tutorial.ID == id {
+			return tutorial, true
+		}
+	}
+	return Tutorial{}, false
+}
+
+// tutorialRun is a sandbox's progress through one tutorial.
+type tutorialRun struct {
+	completedAt []time.Time // completion time of each finished step, in order
+	remembered  map[string]interface{}
+}
+
+// advanceTutorialsLocked completes the next open step of every tutorial the
+// event satisfies. sb.mu must be held.
+func (sb *sandbox) advanceTutorialsLocked(event SandboxEvent) {
+	for _, tutorial := range tutorials {
+		run := sb.tutorialRuns[tutorial.ID]
+		if run == nil {
+			run = &tutorialRun{remembered: map[string]interface{}{}}
+			sb.tutorialRuns[tutorial.ID] = run
+		}
+
+		next := len(run.completedAt)
+		if next == len(tutorial.Steps) || !tutorial.Steps[next].completes(event, run.remembered) {
+			continue
+		}
+		run.completedAt = append(run.completedAt, event.At)
+		for detail, name := range tutorial.Steps[next].remember {
+			run.remembered[name] = event.Details[detail]
+		}
+	}
+}
+
+// TutorialCompletions returns when each finished step of a tutorial was
+// completed, in step order.
+func (sb *sandbox) TutorialCompletions(id string) []time.Time {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if run := sb.tutorialRuns[id]; run != nil {
+		return append([]time.Time{}, run.completedAt...)
+	}
+	return nil
+}
+
+func tutorialProgress(tutorial Tutorial, completedAt []time.Time) []StepProgress {
+	progress := make([]StepProgress, len(tutorial.Steps))
+	for i, step := range tutorial.Steps {
+		progress[i] = StepProgress{TutorialStep: step}
+		if i < len(completedAt) {
+			at := completedAt[i]
+			progress[i].Completed = true
+			progress[i].CompletedAt = &at
+		}
+	}
+	return progress
+}
+
+func tutorialSummary(tutorial Tutorial, progress []StepProgress) map[string]interface{} {
+	completed := 0
+	currentStep := ""
+	for _, step := range progress {
+		if step.Completed {
+			completed++
+		} else if currentStep == "" {
+			currentStep = step.ID
+		}
+	}
+
+	return map[string]interface{}{
+		"id":           tutorial.ID,
+		"title":        tutorial.Title,
+		"description":  tutorial.Description,
+		"total_steps":  len(progress),
+		"completed":    completed,
+		"percent":      completed * 100 / len(progress),
+		"current_step": currentStep,
+	}
+}
+
+func (s *EducationalServer) listTutorials(c *gin.Context) {
+	sb := currentSandbox(c)
+
+	lessons := []map[string]interface{}{}
+	for _, tutorial := range tutorials {
+		lessons = append(lessons, tutorialSummary(tutorial, tutorialProgress(tutorial, sb.TutorialCompletions(tutorial.ID))))
+	}
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Tutorials retrieved",
+		Data:        map[string]interface{}{"tutorials": lessons},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) getTutorial(c *gin.Context) {
+	tutorial, exists := findTutorial(c.Param("id"))
+	if !exists {
+		c.JSON(http.StatusNotFound, DemoResponse{
+			Success:     false,
+			Message:     "Tutorial not found: " + c.Param("id"),
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	progress := tutorialProgress(tutorial, currentSandbox(c).TutorialCompletions(tutorial.ID))
+	lesson := tutorialSummary(tutorial, progress)
+	lesson["steps"] = progress
+
+	c.JSON(http.StatusOK, DemoResponse{
+		Success:     true,
+		Message:     "Tutorial retrieved",
+		Data:        lesson,
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (s *EducationalServer) verifyTutorialStep(c *gin.Context) {
+	tutorial, exists := findTutorial(c.Param("id"))
+	if !exists {
+		c.JSON(http.StatusNotFound, DemoResponse{
+			Success:     false,
+			Message:     "Tutorial not found: " + c.Param("id"),
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	progress := tutorialProgress(tutorial, currentSandbox(c).TutorialCompletions(tutorial.ID))
+	for i, step := range progress {
+		if step.ID != c.Param("step") {
+			continue
+		}
+
+		result := map[string]interface{}{
+			"tutorial": tutorial.ID,
+			"step":     step.ID,
+			"verified": step.Completed,
+		}
+		if step.Completed {
+			result["completed_at"] = step.CompletedAt
+		} else if i > 0 && !progress[i-1].Completed {
+			result["hint"] = "Complete the previous steps first"
+		} else {
+			result["hint"] = step.Instruction + " (" + step.Endpoint + ")"
+		}
+
+		c.JSON(http.StatusOK, DemoResponse{
+			Success:     true,
+			Message:     "Tutorial step checked",
+			Data:        result,
+			Educational: true,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusNotFound, DemoResponse{
+		Success:     false,
+		Message:     "Step not found: " + c.Param("step"),
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API versioning for the educational endpoints.
+// Every version in apiVersions is mounted at /api/<name>/educational with the
+// same handlers; /api/educational negotiates a version from the request.
+
+type apiVersion struct {
+	Name       string
+	Deprecated time.Time // zero while the version is supported
+	Sunset     time.Time // zero when no removal date has been announced
+}
+
+// apiVersions lists the mounted versions, oldest first.
+var apiVersions = []apiVersion{
+	{Name: "v1"},
+}
+
+var (
+	versionSegment  = regexp.MustCompile(`^/api/v[0-9]+/`)
+	vendorMediaType = regexp.MustCompile(`application/vnd\.gauth\.(v[0-9]+)\+json`)
+)
+
+func (s *EducationalServer) registerEducationalRoutes(api *gin.RouterGroup) {
+	api.GET("/health", s.healthCheck)
+	api.GET("/demo/examples", s.listExamples)
+	api.GET("/demo/architecture", s.getArchitecture)
+	api.GET("/flows", s.listFlows)
+	api.GET("/flows/:name", s.getFlow)
+
+	// Stateful demos run against the caller's sandbox tenant
+	sandboxed := api.Group("", s.sandboxSession())
+	sandboxed.POST("/demo/token/create", s.demoCreateToken)
+	sandboxed.POST("/demo/token/validate", s.demoValidateToken)
+	sandboxed.POST("/demo/token/revoke", s.demoRevokeToken)
+	sandboxed.POST("/demo/token/delegate", s.demoDelegateToken)
+	sandboxed.POST("/demo/authz/check", s.demoAuthzCheck)
+	sandboxed.POST("/inspect", s.inspectToken)
+	sandboxed.GET("/sandbox", s.getSandbox)
+	sandboxed.GET("/sandbox/activity", s.getSandboxActivity)
+	sandboxed.POST("/sandbox/reset", s.resetSandbox)
+	sandboxed.POST("/sandbox/console", s.sandboxConsole)
+	sandboxed.GET("/tutorials", s.listTutorials)
+	sandboxed.GET("/tutorials/:id", s.getTutorial)
+	sandboxed.POST("/tutorials/:id/steps/:step/verify", s.verifyTutorialStep)
+	sandboxed.GET("/attacks", s.listAttacks)
+	sandboxed.POST("/attacks/:id/mitigation", s.setAttackMitigation)
+	sandboxed.POST("/attacks/:id/run", s.runAttack)
+}
+
+// versionHeaders advertises the served version and, for deprecated versions,
+// the Deprecation/Sunset headers plus a link to the successor version.
+func versionHeaders(version apiVersion) gin.HandlerFunc {
+	successor := latestAPIVersion()
+
+	return func(c *gin.Context) {
+		c.Header("API-Version", version.Name)
+
+		if !version.Deprecated.IsZero() {
+			// RFC 9745 structured date: "@" followed by a Unix timestamp
+			c.Header("Deprecation", "@"+strconv.FormatInt(version.Deprecated.Unix(), 10))
+			if !version.Sunset.IsZero() {
+				c.Header("Sunset", version.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if successor.Name != version.Name {
+				c.Header("Link", "</api/"+successor.Name+"/educational>; rel=\"successor-version\"")
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// negotiateVersion wraps the router and rewrites /api/educational/* requests
+// to the path of the version picked from the Accept-Version header or an
+// application/vnd.gauth.<version>+json Accept type, falling back to the
+// latest supported version. Rewriting before routing means the request passes
+// through the middleware chain once. Requests for unknown versions are left
+// unchanged and answered by unsupportedVersion.
+func negotiateVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, unversioned := strings.CutPrefix(r.URL.Path, "/api/educational/")
+		if !unversioned {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Version, Accept")
+		if version, exists := negotiatedVersion(r); exists {
+			r = r.Clone(r.Context())
+			r.URL.Path = "/api/" + version.Name + "/educational/" + rest
+			r.URL.RawPath = ""
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestedVersion returns the version named by the request, if any.
+func requestedVersion(r *http.Request) string {
+	if requested := r.Header.Get("Accept-Version"); requested != "" {
+		return requested
+	}
+	if match := vendorMediaType.FindStringSubmatch(r.Header.Get("Accept")); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+func negotiatedVersion(r *http.Request) (apiVersion, bool) {
+	requested := requestedVersion(r)
+	if requested == "" {
+		return latestAPIVersion(), true
+	}
+	return findAPIVersion(requested)
+}
+
+// unsupportedVersion answers /api/educational/* requests that negotiateVersion
+// could not map to a mounted version.
+func (s *EducationalServer) unsupportedVersion(c *gin.Context) {
+	supported := []string{}
+	for _, v := range apiVersions {
+		supported = append(supported, v.Name)
+	}
+	c.JSON(http.StatusNotAcceptable, DemoResponse{
+		Success:     false,
+		Message:     "Unsupported API version: " + requestedVersion(c.Request),
+		Data:        map[string]interface{}{"supported_versions": supported},
+		Educational: true,
+		Timestamp:   time.Now(),
+	})
+}
+
+func findAPIVersion(name string) (apiVersion, bool) {
+	for _, version := range apiVersions {
+		if version.Name == name {
+			return version, true
+		}
+	}
+	return apiVersion{}, false
+}
+
+// latestAPIVersion returns the newest non-deprecated version, or the newest
+// version if every version is deprecated.
+func latestAPIVersion() apiVersion {
+	for i := len(apiVersions) - 1; i >= 0; i-- {
+		if apiVersions[i].Deprecated.IsZero() {
+			return apiVersions[i]
+		}
+	}
+	return apiVersions[len(apiVersions)-1]
+}
+
+// routeVersion returns the version segment of a versioned route path, or ""
+// for unversioned routes.
+func routeVersion(path string) string {
+	if !versionSegment.MatchString(path) {
+		return ""
+	}
+	return strings.SplitN(path, "/", 4)[2]
+}
+
+// unversionedPath maps "/api/v1/educational/health" to "/api/educational/health"
+// so route documentation is shared across versions.
+func unversionedPath(path string) string {
+	if loc := versionSegment.FindStringIndex(path); loc != nil {
+		return "/api/" + path[loc[1]:]
+	}
+	return path
+}
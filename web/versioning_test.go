@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withDeprecatedV1 mounts a deprecated v1 alongside a current v2 for the
+// duration of the test; the server must be created after calling it.
+func withDeprecatedV1(t *testing.T) (deprecated, sunset time.Time) {
+	t.Helper()
+	deprecated = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sunset = time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	previous := apiVersions
+	apiVersions = []apiVersion{
+		{Name: "v1", Deprecated: deprecated, Sunset: sunset},
+		{Name: "v2"},
+	}
+	t.Cleanup(func() { apiVersions = previous })
+	return deprecated, sunset
+}
+
+func TestVersionNegotiation(t *testing.T) {
+	withDeprecatedV1(t)
+	s := NewEducationalServer("")
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+		version string
+	}{
+		{"no preference", nil, http.StatusOK, "v2"},
+		{"Accept-Version", map[string]string{"Accept-Version": "v1"}, http.StatusOK, "v1"},
+		{"vendor media type", map[string]string{"Accept": "application/vnd.gauth.v1+json"}, http.StatusOK, "v1"},
+		{"Accept-Version wins", map[string]string{"Accept-Version": "v2", "Accept": "application/vnd.gauth.v1+json"}, http.StatusOK, "v2"},
+		{"unknown Accept-Version", map[string]string{"Accept-Version": "v9"}, http.StatusNotAcceptable, ""},
+		{"unknown media type version", map[string]string{"Accept": "application/vnd.gauth.v9+json"}, http.StatusNotAcceptable, ""},
+	}
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/api/educational/health", nil)
+		for name, value := range test.headers {
+			request.Header.Set(name, value)
+		}
+		response := serve(t, s, request)
+
+		if response.Code != test.status {
+			t.Errorf("%s: status = %d, want %d", test.name, response.Code, test.status)
+		}
+		if got := response.Header().Get("API-Version"); got != test.version {
+			t.Errorf("%s: API-Version = %q, want %q", test.name, got, test.version)
+		}
+		if vary := response.Header().Get("Vary"); !strings.Contains(vary, "Accept-Version") || !strings.Contains(vary, "Accept") {
+			t.Errorf("%s: Vary = %q, want Accept-Version and Accept", test.name, vary)
+		}
+	}
+}
+
+func TestDeprecatedVersionHeaders(t *testing.T) {
+	deprecated, sunset := withDeprecatedV1(t)
+	s := NewEducationalServer("")
+
+	response := serve(t, s, httptest.NewRequest(http.MethodGet, "/api/v1/educational/health", nil))
+	if response.Code != http.StatusOK {
+		t.Fatalf("GET v1 health = %d, want %d", response.Code, http.StatusOK)
+	}
+	headers := response.Header()
+	if got, want := headers.Get("Deprecation"), "@"+strconv.FormatInt(deprecated.Unix(), 10); got != want {
+		t.Errorf("Deprecation = %q, want %q", got, want)
+	}
+	if got, want := headers.Get("Sunset"), sunset.Format(http.TimeFormat); got != want {
+		t.Errorf("Sunset = %q, want %q", got, want)
+	}
+	if got, want := headers.Get("Link"), `</api/v2/educational>; rel="successor-version"`; got != want {
+		t.Errorf("Link = %q, want %q", got, want)
+	}
+
+	current := serve(t, s, httptest.NewRequest(http.MethodGet, "/api/v2/educational/health", nil)).Header()
+	for _, name := range []string{"Deprecation", "Sunset", "Link"} {
+		if got := current.Get(name); got != "" {
+			t.Errorf("v2 response has %s: %q, want none", name, got)
+		}
+	}
+}